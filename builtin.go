@@ -10,6 +10,63 @@ import (
 	"strings"
 )
 
+//////
+// Helpers.
+//////
+
+// newFailedToError builds, but doesn't emit, see `NewFailedToError`.
+func newFailedToError(message string, opts ...Option) *CustomError {
+	return build(fmt.Sprintf(builtInTemplates[FailedTo], message), builtInOptions(
+		FailedTo,
+		opts,
+		WithStatusCode(http.StatusInternalServerError),
+	)...)
+}
+
+// newInvalidError builds, but doesn't emit, see `NewInvalidError`.
+func newInvalidError(message string, opts ...Option) *CustomError {
+	return build(fmt.Sprintf(builtInTemplates[Invalid], message), builtInOptions(
+		Invalid,
+		opts,
+		WithStatusCode(http.StatusBadRequest),
+	)...)
+}
+
+// newMissingError builds, but doesn't emit, see `NewMissingError`.
+func newMissingError(message string, opts ...Option) *CustomError {
+	return build(fmt.Sprintf(builtInTemplates[Missing], message), builtInOptions(
+		Missing,
+		opts,
+		WithStatusCode(http.StatusBadRequest),
+	)...)
+}
+
+// newRequiredError builds, but doesn't emit, see `NewRequiredError`.
+func newRequiredError(message string, opts ...Option) *CustomError {
+	return build(fmt.Sprintf(builtInTemplates[Required], message), builtInOptions(
+		Required,
+		opts,
+		WithStatusCode(http.StatusBadRequest),
+	)...)
+}
+
+// newNotFoundError builds, but doesn't emit, see `NewNotFoundError`.
+func newNotFoundError(message string, opts ...Option) *CustomError {
+	return build(fmt.Sprintf(builtInTemplates[NotFound], message), builtInOptions(
+		NotFound,
+		opts,
+		WithStatusCode(http.StatusNotFound),
+	)...)
+}
+
+// newHTTPError builds, but doesn't emit, see `NewHTTPError`.
+func newHTTPError(statusCode int, opts ...Option) *CustomError {
+	return build(strings.ToLower(http.StatusText(statusCode)), prependOptions(
+		opts,
+		WithStatusCode(statusCode),
+	)...)
+}
+
 //////
 // Built-in.
 //////
@@ -19,10 +76,7 @@ import (
 //
 // NOTE: Status code can be redefined, call `SetStatusCode`.
 func NewFailedToError(message string, opts ...Option) error {
-	return New(fmt.Sprintf("failed to %s", message), prependOptions(
-		opts,
-		WithStatusCode(http.StatusInternalServerError),
-	)...)
+	return emit(newFailedToError(message, opts...))
 }
 
 // NewInvalidError is the building block for errors usually thrown when
@@ -30,10 +84,7 @@ func NewFailedToError(message string, opts ...Option) error {
 //
 // NOTE: Status code can be redefined, call `SetStatusCode`.
 func NewInvalidError(message string, opts ...Option) error {
-	return New(fmt.Sprintf("invalid %s", message), prependOptions(
-		opts,
-		WithStatusCode(http.StatusBadRequest),
-	)...)
+	return emit(newInvalidError(message, opts...))
 }
 
 // NewMissingError is the building block for errors usually thrown when required
@@ -41,10 +92,7 @@ func NewInvalidError(message string, opts ...Option) error {
 //
 // NOTE: Status code can be redefined, call `SetStatusCode`.
 func NewMissingError(message string, opts ...Option) error {
-	return New(fmt.Sprintf("missing %s", message), prependOptions(
-		opts,
-		WithStatusCode(http.StatusBadRequest),
-	)...)
+	return emit(newMissingError(message, opts...))
 }
 
 // NewRequiredError is the building block for errors usually thrown when
@@ -53,10 +101,7 @@ func NewMissingError(message string, opts ...Option) error {
 //
 // NOTE: Status code can be redefined, call `SetStatusCode`.
 func NewRequiredError(message string, opts ...Option) error {
-	return New(fmt.Sprintf("%s required", message), prependOptions(
-		opts,
-		WithStatusCode(http.StatusBadRequest),
-	)...)
+	return emit(newRequiredError(message, opts...))
 }
 
 // NewNotFoundError is the building block for errors usually thrown when something
@@ -64,16 +109,12 @@ func NewRequiredError(message string, opts ...Option) error {
 //
 // NOTE: Status code can be redefined, call `SetStatusCode`.
 func NewNotFoundError(message string, opts ...Option) error {
-	return New(fmt.Sprintf("%s not found", message), prependOptions(
-		opts,
-		WithStatusCode(http.StatusNotFound),
-	)...)
+	return emit(newNotFoundError(message, opts...))
 }
 
 // NewHTTPError is the building block for simple HTTP errors, e.g.: Not Found.
+// Default severity is derived from the status code: server errors are `error`,
+// anything else is `warning`.
 func NewHTTPError(statusCode int, opts ...Option) error {
-	return New(strings.ToLower(http.StatusText(statusCode)), prependOptions(
-		opts,
-		WithStatusCode(statusCode),
-	)...)
+	return emit(newHTTPError(statusCode, opts...))
 }
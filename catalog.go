@@ -3,10 +3,10 @@ package customerror
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
-
-	"github.com/go-playground/validator/v10"
+	"unicode/utf8"
 )
 
 //////
@@ -18,6 +18,21 @@ var (
 	// the catalog.
 	ErrCatalogErrorNotFound = NewNotFoundError("error", WithErrorCode("CE_ERR_CATALOG_ERR_NOT_FOUND"))
 
+	// ErrCatalogCodeConflict is returned when an error code is in more than
+	// one catalog of a set.
+	ErrCatalogCodeConflict = NewInvalidError("error code. It's in more than one catalog", WithErrorCode("CE_ERR_CATALOG_CODE_CONFLICT"))
+
+	// ErrCatalogDuplicatedCode is returned when an error code is already in
+	// the catalog.
+	ErrCatalogDuplicatedCode = NewInvalidError("error code. It's already in the catalog", WithErrorCode("CE_ERR_CATALOG_DUPLICATED_CODE"))
+
+	// ErrCatalogDuplicatedName is returned when a catalog name is already in a
+	// set.
+	ErrCatalogDuplicatedName = NewInvalidError("name. It's already in the catalog set", WithErrorCode("CE_ERR_CATALOG_DUPLICATED_NAME"))
+
+	// ErrCatalogInvalid is returned when a catalog has invalid errors.
+	ErrCatalogInvalid = NewInvalidError("catalog", WithErrorCode("CE_ERR_CATALOG_INVALID"))
+
 	// ErrCatalogInvalidName is returned when a catalog name is invalid.
 	ErrCatalogInvalidName = NewInvalidError("name", WithErrorCode("CE_ERR_CATALOG_INVALID_NAME"))
 
@@ -43,11 +58,14 @@ var (
 	// Format: E{1 to 8 digits}
 	// Example: E12345678
 	//
-	// 4. At least one letter or number (any combination of uppercase and
-	// lowercase letters and digits):
-	// Format: {letters or digits, at least one character}
-	// Example: AbCd123.
-	ErrorCodeRegex = regexp.MustCompile(`^(\d?[A-Za-z]_)?ERR_[A-Za-z]\d_[A-Za-z]\d(_\d[A-Za-z])?$|^ERR_[A-Za-z]\d_[A-Za-z]\d(_\d[A-Za-z])?$|^E\d{1,8}$|[A-Za-z\d]+`)
+	// 4. Words of letters, and numbers (any combination of uppercase and
+	// lowercase letters and digits), separated by single underscores:
+	// Format: {letters or digits}_{letters or digits}...
+	// Example: AbCd123, or INVALID_REQUEST.
+	//
+	// Any other character, including spaces, isn't allowed. For stricter
+	// rules, see `CodePolicy`.
+	ErrorCodeRegex = regexp.MustCompile(`^(\d?[A-Za-z]_)?ERR_[A-Za-z]\d_[A-Za-z]\d(_\d[A-Za-z])?$|^ERR_[A-Za-z]\d_[A-Za-z]\d(_\d[A-Za-z])?$|^E\d{1,8}$|^[A-Za-z\d]+(_[A-Za-z\d]+)*$`)
 )
 
 type (
@@ -56,6 +74,9 @@ type (
 	// separated by underscore, example: "INVALID_REQUEST".
 	ErrorCode string
 
+	// Filter filters catalog errors, see `Catalog.Query`.
+	Filter func(code string, cE *CustomError) bool
+
 	// ErrorCodeErrorMap is a map of error codes to custom errors.
 	ErrorCodeErrorMap = *sync.Map
 
@@ -66,9 +87,72 @@ type (
 
 		// Name of the catalog, usually, the name of the application.
 		Name string `json:"name" validate:"required,gt=3"`
+
+		// Aliases to codes, see `Alias`.
+		aliases sync.Map
+
+		// Validates the codes, see `WithCodePolicy`.
+		codePolicy CodePolicy
+
+		// Prefix of the codes, if any, see `WithCodePrefix`.
+		prefix string
+
+		// Templates of the errors, see `WithCatalogErrorTypeTemplate`.
+		templates LanguageErrorMap
 	}
 )
 
+// NamespaceSeparator separates the catalog name from the code in fully
+// qualified codes, e.g.: "myapp:ERR_A1_B2".
+const NamespaceSeparator = ":"
+
+//////
+// Helpers.
+//////
+
+// codePrefixOf returns the code prefix for the catalog `name`: upper cased,
+// non-alphanumeric characters replaced with underscore.
+func codePrefixOf(name string) string {
+	prefix := strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+
+		return '_'
+	}, strings.ToUpper(name))
+
+	return strings.Trim(prefix, "_")
+}
+
+// resolve returns the code stored in the catalog for `errorCode`, which may be
+// fully qualified, and prefixed or not. The code is validated by the catalog
+// code policy.
+func (c *Catalog) resolve(errorCode string) (ErrorCode, error) {
+	errorCode = strings.ToUpper(strings.TrimPrefix(errorCode, c.Name+NamespaceSeparator))
+
+	if c.prefix != "" {
+		errorCode = strings.TrimPrefix(errorCode, c.prefix+"_")
+	}
+
+	eC := ErrorCode(errorCode)
+
+	// Catalogs may not be created with `NewCatalog`.
+	policy := c.codePolicy
+	if policy == nil {
+		policy = DefaultCodePolicy
+	}
+
+	if err := policy(eC); err != nil {
+		return "", err
+	}
+
+	if c.prefix != "" {
+		eC = ErrorCode(c.prefix + "_" + errorCode)
+	}
+
+	return eC, nil
+}
+
 //////
 // Methods.
 //////
@@ -89,13 +173,39 @@ func (e ErrorCode) Validate() error {
 
 // Set a custom error to the catalog. Use options to set default and common
 // values such as fields, tags, etc.
+//
+// If the catalog has a code prefix (`WithCodePrefix`), `errorCode` is
+// prefixed, and the error has it as `Code`, unless set by `opts`. The final
+// code is returned. Codes already in the catalog - e.g.: "NOT_FOUND", and
+// "USER_NOT_FOUND" in the "user" catalog - are rejected.
 func (c *Catalog) Set(errorCode string, defaultMessage string, opts ...Option) (string, error) {
-	eC, err := NewErrorCode(errorCode)
+	eC, err := c.resolve(errorCode)
 	if err != nil {
 		return "", err
 	}
 
-	c.ErrorCodeErrorMap.Store(eC, Factory(defaultMessage, opts...))
+	if code, ok := c.aliases.Load(eC); ok {
+		return "", fmt.Errorf("%w. Alias: %s. Code: %s", ErrCatalogAliasConflict, eC, code)
+	}
+
+	if c.prefix != "" {
+		opts = prependOptions(opts, WithErrorCode(eC.String()))
+	}
+
+	if c.templates != nil {
+		// Copied, `opts` may be shared by the caller.
+		opts = append(append([]Option{}, opts...), withErrorTypeTemplates(c.templates))
+	}
+
+	cE := Factory(defaultMessage, opts...)
+
+	if cE != nil {
+		cE.catalog = c
+	}
+
+	if _, loaded := c.ErrorCodeErrorMap.LoadOrStore(eC, cE); loaded {
+		return "", fmt.Errorf("%w. Code: %s", ErrCatalogDuplicatedCode, eC)
+	}
 
 	return eC.String(), nil
 }
@@ -111,18 +221,59 @@ func (c *Catalog) MustSet(errorCode string, defaultMessage string, opts ...Optio
 }
 
 // Get returns a custom error from the catalog, if not found, returns an error.
+// `errorCode` may be fully qualified (e.g.: "myapp:ERR_A1_B2"), and, if the
+// catalog has a code prefix, prefixed or not.
+// The error is a copy of the catalog one, with `opts` applied. Like
+// factories, it isn't emitted - hooks aren't called, nor observers notified -,
+// factory methods such as `NewInvalidError` emit the errors built from it. To
+// return it as it is, use `Emit` instead.
+//
+// `errorCode` may be an alias (see `Alias`), then the error is deprecated in
+// favor of its code, see `OnDeprecated`.
 func (c *Catalog) Get(errorCode string, opts ...Option) (*CustomError, error) {
-	errCode, err := NewErrorCode(errorCode)
+	errCode, err := c.resolve(errorCode)
 	if err != nil {
 		return nil, err
 	}
 
-	customErr, ok := c.ErrorCodeErrorMap.Load(errCode)
-	if ok {
-		return customErr.(*CustomError), nil
+	customErr, code, ok := c.lookup(errCode)
+	if !ok {
+		return nil, fmt.Errorf("%w. Code: %s", ErrCatalogErrorNotFound, errCode)
 	}
 
-	return nil, fmt.Errorf("%w. Code: %s", ErrCatalogErrorNotFound, errCode)
+	cE := Copy(customErr, &CustomError{})
+
+	deprecate(cE, errCode, code)
+
+	// Apply options.
+	for _, opt := range opts {
+		opt(cE)
+	}
+
+	return cE, nil
+}
+
+// Emit is like `Get`, but the error is emitted: hooks are called, and
+// observers notified, so it's ready to be returned. If vetoed by a hook, it
+// returns nil, like ignored errors.
+func (c *Catalog) Emit(errorCode string, opts ...Option) (*CustomError, error) {
+	cE, err := c.Get(errorCode, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return finalize(cE), nil
+}
+
+// Catalog returns the catalog the error comes from - got from it, or created
+// from one of its entries -, if any.
+func (cE *CustomError) Catalog() *Catalog {
+	return cE.catalog
+}
+
+// Prefix returns the code prefix, if any, see `WithCodePrefix`.
+func (c *Catalog) Prefix() string {
+	return c.prefix
 }
 
 // MustGet returns a custom error from the catalog, if not found, panics.
@@ -135,6 +286,166 @@ func (c *Catalog) MustGet(errorCode string, opts ...Option) *CustomError {
 	return customErr
 }
 
+// Codes returns the codes in the catalog, sorted.
+func (c *Catalog) Codes() []string {
+	var codes []string
+
+	c.ErrorCodeErrorMap.Range(func(key, _ any) bool {
+		codes = append(codes, key.(ErrorCode).String())
+
+		return true
+	})
+
+	sort.Strings(codes)
+
+	return codes
+}
+
+// Len returns the number of errors in the catalog.
+func (c *Catalog) Len() int {
+	n := 0
+
+	c.ErrorCodeErrorMap.Range(func(_, _ any) bool {
+		n++
+
+		return true
+	})
+
+	return n
+}
+
+// Range calls `fn` for each error in the catalog, sorted by code. The error is
+// a copy of the catalog one - changing it doesn't change the catalog. If `fn`
+// returns false, iteration stops.
+func (c *Catalog) Range(fn func(code string, cE *CustomError) bool) {
+	for _, code := range c.Codes() {
+		customErr, ok := c.ErrorCodeErrorMap.Load(ErrorCode(code))
+		if !ok {
+			// Deleted meanwhile.
+			continue
+		}
+
+		if !fn(code, Copy(customErr.(*CustomError), &CustomError{})) {
+			return
+		}
+	}
+}
+
+// Delete removes an error, and its aliases, from the catalog. It returns false
+// if not found.
+func (c *Catalog) Delete(errorCode string) bool {
+	errCode, err := c.resolve(errorCode)
+	if err != nil {
+		return false
+	}
+
+	_, ok := c.ErrorCodeErrorMap.LoadAndDelete(errCode)
+
+	c.aliases.Range(func(alias, code any) bool {
+		if code.(ErrorCode) == errCode {
+			c.aliases.Delete(alias)
+		}
+
+		return true
+	})
+
+	return ok
+}
+
+// Query returns the codes of the errors matching all `filters`, sorted. No
+// filters match all errors.
+func (c *Catalog) Query(filters ...Filter) []string {
+	var codes []string
+
+	c.Range(func(code string, cE *CustomError) bool {
+		for _, filter := range filters {
+			if !filter(code, cE) {
+				return true
+			}
+		}
+
+		codes = append(codes, code)
+
+		return true
+	})
+
+	return codes
+}
+
+// Validate validates every error in the catalog: code (see `WithCodePolicy`),
+// message, status code, translations, and deprecation replacement code. All
+// problems are reported at once.
+func (c *Catalog) Validate() error {
+	var problems []string
+
+	c.Range(func(code string, cE *CustomError) bool {
+		if _, err := c.resolve(code); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", code, err))
+		}
+
+		if err := getValidator().Struct(cE); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", code, err))
+		}
+
+		if d, ok := cE.Deprecation(); ok && c.isLocal(d.Replacement) {
+			if err := c.exists(d.Replacement); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: replacement: %s", code, err))
+			}
+		}
+
+		languages := make([]string, 0, len(cE.LanguageMessageMap))
+
+		for lang := range cE.LanguageMessageMap {
+			languages = append(languages, lang.String())
+		}
+
+		sort.Strings(languages)
+
+		for _, lang := range languages {
+			if err := Language(lang).Validate(); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s: %s", code, lang, err))
+			}
+
+			if utf8.RuneCountInString(cE.LanguageMessageMap[Language(lang)]) < 3 {
+				problems = append(problems, fmt.Sprintf("%s: %s: %s", code, lang, ErrInvalidLanguageErrorMessage))
+			}
+		}
+
+		return true
+	})
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w. %s", ErrCatalogInvalid, strings.Join(problems, ". "))
+	}
+
+	return nil
+}
+
+//////
+// Exported functionalities.
+//////
+
+// ByErrorType filters errors by type, see `WithErrorType`.
+func ByErrorType(errorType ErrorType) Filter {
+	return func(_ string, cE *CustomError) bool {
+		return cE.errorType == errorType
+	}
+}
+
+// ByStatusCode filters errors by status code, from `from` to `to`, inclusive.
+func ByStatusCode(from, to int) Filter {
+	return func(_ string, cE *CustomError) bool {
+		return cE.StatusCode >= from && cE.StatusCode <= to
+	}
+}
+
+// ByTag filters errors by tag.
+func ByTag(tag string) Filter {
+	return func(_ string, cE *CustomError) bool {
+		return cE.Tags.Contains(tag)
+	}
+}
+
 //////
 // Factory.
 //////
@@ -152,22 +463,28 @@ func NewErrorCode(name string) (ErrorCode, error) {
 }
 
 // NewCatalog creates a new Catalog.
-func NewCatalog(name string) (*Catalog, error) {
+func NewCatalog(name string, opts ...CatalogOption) (*Catalog, error) {
 	c := &Catalog{
 		ErrorCodeErrorMap: &sync.Map{},
 		Name:              name,
+		codePolicy:        DefaultCodePolicy,
 	}
 
-	if err := validator.New().Struct(c); err != nil {
+	if err := getValidator().Struct(c); err != nil {
 		return nil, ErrCatalogInvalidName
 	}
 
+	// Apply options.
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	return c, nil
 }
 
 // MustNewCatalog creates a new Catalog. If an error occurs, panics.
-func MustNewCatalog(name string) *Catalog {
-	c, err := NewCatalog(name)
+func MustNewCatalog(name string, opts ...CatalogOption) *Catalog {
+	c, err := NewCatalog(name, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -125,3 +125,210 @@ func TestNewCatalog_2(t *testing.T) {
 		t.Fatalf("Got %s Expected %s", x5, "no content")
 	}
 }
+
+func BenchmarkCatalog_Get(b *testing.B) {
+	catalog := MustNewCatalog("myapp").
+		MustSet("E1010", "invalid response").
+		MustSet("INVALID_REQUEST_BODY", "invalid request body", WithTranslation("pt-BR", "corpo da solicitação inválido"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = catalog.Get("INVALID_REQUEST_BODY")
+	}
+}
+
+func BenchmarkCatalog_Get_withLanguage(b *testing.B) {
+	catalog := MustNewCatalog("myapp").
+		MustSet("INVALID_REQUEST_BODY", "invalid request body", WithTranslation("pt-BR", "corpo da solicitação inválido"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cE, _ := catalog.Get("INVALID_REQUEST_BODY")
+
+		_ = cE.New(WithLanguage("pt-BR"))
+	}
+}
+
+func TestCatalog_WithCodePrefix(t *testing.T) {
+	catalog := MustNewCatalog("my-app", WithCodePrefix())
+
+	code, err := catalog.Set("err_a1_b2", "invalid response")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "MY_APP_ERR_A1_B2", code)
+	assert.Equal(t, "MY_APP", catalog.Prefix())
+
+	for _, errorCode := range []string{"ERR_A1_B2", "MY_APP_ERR_A1_B2", "my-app:ERR_A1_B2"} {
+		cE, err := catalog.Get(errorCode)
+
+		if assert.NoError(t, err, errorCode) {
+			assert.EqualError(t, cE, "MY_APP_ERR_A1_B2: invalid response")
+			assert.Same(t, catalog, cE.Catalog())
+
+			// Errors created from the entry also know their catalog.
+			//nolint:errorlint
+			assert.Same(t, catalog, cE.NewInvalidError().(*CustomError).Catalog())
+		}
+	}
+
+	// Without prefix, codes, and errors are as they are.
+	catalog = MustNewCatalog("myapp").MustSet("ERR_A1_B2", "invalid response")
+
+	cE := catalog.MustGet("ERR_A1_B2")
+
+	assert.EqualError(t, cE, "invalid response")
+	assert.Empty(t, catalog.Prefix())
+	assert.Nil(t, Factory("invalid response").Catalog())
+}
+
+func TestCatalog_Set_duplicatedCode(t *testing.T) {
+	catalog := MustNewCatalog("user", WithCodePrefix()).MustSet("USER_NOT_FOUND", "user not found")
+
+	for _, errorCode := range []string{"NOT_FOUND", "USER_NOT_FOUND", "user:NOT_FOUND"} {
+		_, err := catalog.Set(errorCode, "not found")
+
+		assert.ErrorIs(t, err, ErrCatalogDuplicatedCode, errorCode)
+	}
+
+	// Not replaced.
+	assert.EqualError(t, catalog.MustGet("NOT_FOUND"), "USER_NOT_FOUND: user not found")
+}
+
+func TestCatalog_Set_options(t *testing.T) {
+	catalog := MustNewCatalog("myapp", WithCatalogErrorTypeTemplate("en", Invalid, "%s is invalid"))
+
+	opts := make([]Option, 1, 2)
+
+	opts[0] = WithStatusCode(http.StatusBadRequest)
+
+	catalog.MustSet("E1010", "id", opts...)
+
+	// The spare capacity of the caller's options isn't written.
+	assert.Nil(t, opts[:2][1])
+	assert.EqualError(t, catalog.MustGet("E1010").NewInvalidError(), "id is invalid")
+}
+
+func TestCatalog_listing(t *testing.T) {
+	catalog := MustNewCatalog("myapp").
+		MustSet("E1012", "invalid request").
+		MustSet("E1010", "invalid response").
+		MustSet("E1011", "invalid body")
+
+	assert.Equal(t, []string{"E1010", "E1011", "E1012"}, catalog.Codes())
+	assert.Equal(t, 3, catalog.Len())
+
+	var messages []string
+
+	catalog.Range(func(code string, cE *CustomError) bool {
+		messages = append(messages, cE.Message)
+
+		// Changing it doesn't change the catalog.
+		cE.Message = "changed"
+
+		return code != "E1011"
+	})
+
+	assert.Equal(t, []string{"invalid response", "invalid body"}, messages)
+	assert.Equal(t, "invalid response", catalog.MustGet("E1010").Message)
+
+	assert.True(t, catalog.Delete("e1010"))
+	assert.False(t, catalog.Delete("E1010"))
+	assert.Equal(t, []string{"E1011", "E1012"}, catalog.Codes())
+	assert.Equal(t, 2, catalog.Len())
+	assert.Empty(t, MustNewCatalog("myapp").Codes())
+}
+
+func TestCatalog_Query(t *testing.T) {
+	catalog := MustNewCatalog("myapp").
+		MustSet("E1010", "user not found", WithStatusCode(http.StatusNotFound), WithErrorType(NotFound), WithTag("users")).
+		MustSet("E1011", "invalid user", WithStatusCode(http.StatusBadRequest), WithErrorType(Invalid), WithTag("users")).
+		MustSet("E1012", "database down", WithStatusCode(http.StatusServiceUnavailable), WithTag("db")).
+		MustSet("E1013", "invalid invoice", WithStatusCode(http.StatusUnprocessableEntity), WithErrorType(Invalid))
+
+	tests := []struct {
+		name    string
+		filters []Filter
+		want    []string
+	}{
+		{
+			name: "Should match all - no filters",
+			want: []string{"E1010", "E1011", "E1012", "E1013"},
+		},
+		{
+			name:    "Should filter by tag",
+			filters: []Filter{ByTag("users")},
+			want:    []string{"E1010", "E1011"},
+		},
+		{
+			name:    "Should filter by status code",
+			filters: []Filter{ByStatusCode(400, 499)},
+			want:    []string{"E1010", "E1011", "E1013"},
+		},
+		{
+			name:    "Should filter by error type",
+			filters: []Filter{ByErrorType(Invalid)},
+			want:    []string{"E1011", "E1013"},
+		},
+		{
+			name:    "Should match all filters",
+			filters: []Filter{ByErrorType(Invalid), ByTag("users")},
+			want:    []string{"E1011"},
+		},
+		{
+			name:    "Should match none",
+			filters: []Filter{ByStatusCode(200, 299)},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, catalog.Query(tt.filters...))
+		})
+	}
+}
+
+func TestCatalog_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		catalog      *Catalog
+		wantProblems []string
+	}{
+		{
+			name: "Should work",
+			catalog: MustNewCatalog("myapp").
+				MustSet("E1010", "invalid response", WithStatusCode(http.StatusBadGateway), WithTranslation("pt-BR", "resposta inválida")),
+		},
+		{
+			name: "Should fail",
+			catalog: MustNewCatalog("myapp").
+				MustSet("E1010", "no", WithTranslation("pt-BR", "não")).
+				MustSet("E1011", "invalid response", WithStatusCode(600), WithTranslation("es", "x")),
+			wantProblems: []string{
+				"E1010: Key: 'CustomError.Message' Error:Field validation for 'Message' failed on the 'gte' tag",
+				"E1011: Key: 'CustomError.StatusCode' Error:Field validation for 'StatusCode' failed on the 'lte' tag",
+				"E1011: es: CE_ERR_INVALID_LANG_ERROR_MESSAGE: invalid it must be a string, at least 3 characters long",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.catalog.Validate()
+
+			if tt.wantProblems == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.True(t, errors.Is(err, ErrCatalogInvalid))
+
+			for _, problem := range tt.wantProblems {
+				assert.ErrorContains(t, err, problem)
+			}
+		})
+	}
+}
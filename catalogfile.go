@@ -0,0 +1,252 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+type (
+	// CatalogFile is the file format of catalogs, JSON encoded, e.g.:
+	//
+	//	{
+	//	  "name": "myapp",
+	//	  "errors": [
+	//	    {
+	//	      "code": "E1010",
+	//	      "message": "user not found",
+	//	      "statusCode": 404,
+	//	      "translations": {"pt-BR": "usuário não encontrado"}
+	//	    },
+	//	    {
+	//	      "code": "E1011",
+	//	      "message": "invalid user",
+	//	      "aliases": ["E0011"],
+	//	      "deprecation": {"replacement": "E1010", "sunset": "2027-01-01T00:00:00Z"}
+	//	    }
+	//	  ]
+	//	}
+	//
+	// See `Catalog.Export`, and `ReadCatalog`.
+	CatalogFile struct {
+		// Errors of the catalog, sorted by code.
+		Errors []CatalogEntry `json:"errors"`
+
+		// Name of the catalog.
+		Name string `json:"name"`
+	}
+
+	// CatalogEntry is an error of a catalog file.
+	CatalogEntry struct {
+		// Aliases of the code, see `Catalog.Alias`.
+		Aliases []string `json:"aliases,omitempty"`
+
+		// Code of the error in the catalog.
+		Code string `json:"code"`
+
+		// Deprecation of the error, if deprecated, see `WithDeprecation`.
+		Deprecation *CatalogDeprecation `json:"deprecation,omitempty"`
+
+		// ErrorType of the error, if any.
+		ErrorType ErrorType `json:"errorType,omitempty"`
+
+		// Fields are the default fields. Sensitive values are masked.
+		Fields map[string]any `json:"fields,omitempty"`
+
+		// Message is the default message.
+		Message string `json:"message"`
+
+		// Severity of the error.
+		Severity Severity `json:"severity,omitempty"`
+
+		// StatusCode of the error, if any.
+		StatusCode int `json:"statusCode,omitempty"`
+
+		// Tags of the error.
+		Tags []string `json:"tags,omitempty"`
+
+		// Translations of the message.
+		Translations map[Language]string `json:"translations,omitempty"`
+	}
+
+	// CatalogDeprecation is the deprecation of an error of a catalog file.
+	CatalogDeprecation struct {
+		// Replacement is the code which replaces it, if any.
+		Replacement string `json:"replacement,omitempty"`
+
+		// Sunset is when it's removed, if known.
+		Sunset *time.Time `json:"sunset,omitempty"`
+	}
+)
+
+//////
+// Helpers.
+//////
+
+// newCatalogEntry creates a catalog entry from `cE`, with `aliases`.
+func newCatalogEntry(code string, aliases []string, cE *CustomError) CatalogEntry {
+	entry := CatalogEntry{
+		Aliases:    aliases,
+		Code:       code,
+		ErrorType:  cE.errorType,
+		Message:    cE.Message,
+		Severity:   cE.Severity,
+		StatusCode: cE.StatusCode,
+	}
+
+	if cE.deprecation != nil {
+		entry.Deprecation = &CatalogDeprecation{Replacement: cE.deprecation.Replacement}
+
+		if sunset := cE.deprecation.Sunset; !sunset.IsZero() {
+			entry.Deprecation.Sunset = &sunset
+		}
+	}
+
+	if fields := cE.RenderedFields(); fields.Len() > 0 {
+		entry.Fields = fields.ToMap()
+	}
+
+	if !cE.Tags.Empty() {
+		entry.Tags = cE.Tags.Values()
+	}
+
+	if len(cE.LanguageMessageMap) > 0 {
+		entry.Translations = make(map[Language]string, len(cE.LanguageMessageMap))
+
+		for lang, message := range cE.LanguageMessageMap {
+			entry.Translations[lang] = message
+		}
+	}
+
+	return entry
+}
+
+// options returns the options to set the entry in a catalog.
+func (e CatalogEntry) options() ([]Option, error) {
+	var opts []Option
+
+	if e.Deprecation != nil {
+		var sunset time.Time
+
+		if e.Deprecation.Sunset != nil {
+			sunset = *e.Deprecation.Sunset
+		}
+
+		opts = append(opts, WithDeprecation(e.Deprecation.Replacement, sunset))
+	}
+
+	if e.ErrorType != "" {
+		opts = append(opts, WithErrorType(e.ErrorType))
+	}
+
+	if len(e.Fields) > 0 {
+		opts = append(opts, WithFields(e.Fields))
+	}
+
+	if e.Severity != 0 {
+		opts = append(opts, WithSeverity(e.Severity))
+	}
+
+	if e.StatusCode != 0 {
+		opts = append(opts, WithStatusCode(e.StatusCode))
+	}
+
+	if len(e.Tags) > 0 {
+		opts = append(opts, WithTag(e.Tags...))
+	}
+
+	for lang, message := range e.Translations {
+		if err := lang.Validate(); err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, WithTranslation(lang.String(), message))
+	}
+
+	return opts, nil
+}
+
+//////
+// Methods.
+//////
+
+// Export returns the catalog in the file format, see `CatalogFile`.
+func (c *Catalog) Export() CatalogFile {
+	file := CatalogFile{
+		Errors: []CatalogEntry{},
+		Name:   c.Name,
+	}
+
+	c.RangeEntries(func(entry CatalogEntry, _ *CustomError) bool {
+		file.Errors = append(file.Errors, entry)
+
+		return true
+	})
+
+	return file
+}
+
+// RangeEntries is like `Range`, but also calls `fn` with the entry of each
+// error in the file format, see `CatalogFile`. Both are of the same snapshot.
+func (c *Catalog) RangeEntries(fn func(entry CatalogEntry, cE *CustomError) bool) {
+	c.Range(func(code string, cE *CustomError) bool {
+		return fn(newCatalogEntry(code, c.Aliases(code), cE), cE)
+	})
+}
+
+// MarshalJSON implements the json.Marshaler interface. It's the file format,
+// see `CatalogFile`.
+func (c *Catalog) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Export())
+}
+
+//////
+// Exported functionalities.
+//////
+
+// ReadCatalog reads a catalog in the file format, see `CatalogFile`. The
+// catalog is validated, see `Catalog.Validate`.
+func ReadCatalog(r io.Reader, opts ...CatalogOption) (*Catalog, error) {
+	var file CatalogFile
+
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, NewFailedToError("decode catalog", WithError(err), WithErrorCode("CE_ERR_FAILED_TO_DECODE_CATALOG"))
+	}
+
+	c, err := NewCatalog(file.Name, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range file.Errors {
+		entryOpts, err := entry.options()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := c.Set(entry.Code, entry.Message, entryOpts...); err != nil {
+			return nil, err
+		}
+	}
+
+	// Once all codes are set, aliases can't conflict with them later.
+	for _, entry := range file.Errors {
+		if err := c.Alias(entry.Code, entry.Aliases...); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
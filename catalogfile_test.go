@@ -0,0 +1,197 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_Export(t *testing.T) {
+	catalog := MustNewCatalog("myapp", WithCodePrefix()).
+		MustSet(
+			"ERR_A1_B2", "user not found",
+			WithStatusCode(http.StatusNotFound),
+			WithErrorType(NotFound),
+			WithTag("users", "auth"),
+			WithField("retries", 3),
+			WithSensitiveField("token", "xyz"),
+			WithTranslation("pt-BR", "usuário não encontrado"),
+		).
+		MustSet("E1010", "invalid response")
+
+	b, err := json.Marshal(catalog)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "myapp",
+		"errors": [
+			{"code": "MYAPP_E1010", "message": "invalid response", "severity": "error"},
+			{
+				"code": "MYAPP_ERR_A1_B2",
+				"errorType": "not found",
+				"fields": {"retries": 3, "token": "[REDACTED]"},
+				"message": "user not found",
+				"severity": "warning",
+				"statusCode": 404,
+				"tags": ["auth", "users"],
+				"translations": {"pt-BR": "usuário não encontrado"}
+			}
+		]
+	}`, string(b))
+
+	// Round trip.
+	read, err := ReadCatalog(strings.NewReader(string(b)), WithCodePrefix())
+
+	if assert.NoError(t, err) {
+		readB, err := json.Marshal(read)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(b), string(readB))
+
+		assert.EqualError(t, read.MustGet("ERR_A1_B2", WithLanguage("pt-BR")), "MYAPP_ERR_A1_B2: usuário não encontrado. Tags: auth, users. Fields: retries=3, token=[REDACTED]")
+	}
+}
+
+func TestCatalog_Export_deprecation(t *testing.T) {
+	catalog := MustNewCatalog("myapp").
+		MustSet("E2020", "user not found").
+		MustSet("E1011", "invalid user", WithDeprecation("E2020", testSunset)).
+		MustAlias("E2020", "E1010")
+
+	b, err := json.Marshal(catalog)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "myapp",
+		"errors": [
+			{
+				"code": "E1011",
+				"deprecation": {"replacement": "E2020", "sunset": "2027-01-01T00:00:00Z"},
+				"message": "invalid user",
+				"severity": "error"
+			},
+			{"aliases": ["E1010"], "code": "E2020", "message": "user not found", "severity": "error"}
+		]
+	}`, string(b))
+
+	// Round trip.
+	read, err := ReadCatalog(strings.NewReader(string(b)))
+
+	if assert.NoError(t, err) {
+		readB, err := json.Marshal(read)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(b), string(readB))
+
+		d, ok := read.MustGet("E1010").Deprecation()
+
+		assert.True(t, ok)
+		assert.Equal(t, "E2020", d.Replacement)
+	}
+}
+
+func TestReadCatalog(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{
+			name:    "Should work",
+			content: `{"name": "myapp", "errors": [{"code": "E1010", "message": "invalid response"}]}`,
+		},
+		{
+			name:    "Should fail - invalid JSON",
+			content: `{"name": "myapp", "errors": [`,
+			wantErr: errors.New("failed to decode catalog"),
+		},
+		{
+			name:    "Should fail - invalid name",
+			content: `{"name": "my", "errors": []}`,
+			wantErr: ErrCatalogInvalidName,
+		},
+		{
+			name:    "Should fail - invalid code",
+			content: `{"name": "myapp", "errors": [{"code": "!!!", "message": "invalid response"}]}`,
+			wantErr: ErrErrorCodeInvalidCode,
+		},
+		{
+			name:    "Should fail - invalid language",
+			content: `{"name": "myapp", "errors": [{"code": "E1010", "message": "invalid response", "translations": {"pt_BR": "resposta inválida"}}]}`,
+			wantErr: ErrInvalidLanguageCode,
+		},
+		{
+			name:    "Should fail - alias conflict",
+			content: `{"name": "myapp", "errors": [{"code": "E1010", "message": "invalid response"}, {"code": "E1011", "message": "invalid user", "aliases": ["E1010"]}]}`,
+			wantErr: ErrCatalogAliasConflict,
+		},
+		{
+			name:    "Should fail - unknown replacement",
+			content: `{"name": "myapp", "errors": [{"code": "E1010", "message": "invalid response", "deprecation": {"replacement": "E2020"}}]}`,
+			wantErr: ErrCatalogInvalid,
+		},
+		{
+			name:    "Should fail - invalid entry",
+			content: `{"name": "myapp", "errors": [{"code": "E1010", "message": "invalid response", "statusCode": 600}]}`,
+			wantErr: ErrCatalogInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ReadCatalog(strings.NewReader(tt.content))
+
+			if tt.wantErr != nil {
+				if errors.Is(err, tt.wantErr) {
+					return
+				}
+
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, []string{"E1010"}, c.Codes())
+			}
+		})
+	}
+}
+
+func TestCatalog_RangeEntries(t *testing.T) {
+	catalog := MustNewCatalog("myapp").
+		MustSet("E1010", "invalid response", WithStatusCode(http.StatusBadRequest)).
+		MustSet("E2020", "user not found").
+		MustAlias("E2020", "E2000")
+
+	var codes []string
+
+	catalog.RangeEntries(func(entry CatalogEntry, cE *CustomError) bool {
+		codes = append(codes, entry.Code)
+
+		assert.Equal(t, entry.Message, cE.Message)
+		assert.Equal(t, entry.StatusCode, cE.StatusCode)
+
+		// Stop.
+		return entry.Code != "E2020"
+	})
+
+	assert.Equal(t, []string{"E1010", "E2020"}, codes)
+
+	entries := 0
+
+	catalog.RangeEntries(func(entry CatalogEntry, _ *CustomError) bool {
+		entries++
+
+		return false
+	})
+
+	assert.Equal(t, 1, entries)
+}
@@ -0,0 +1,200 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// CatalogSet merges multiple catalogs, e.g.: from different services, or
+// libraries. Catalog names are unique, and so are codes across catalogs. It's
+// safe for concurrent use.
+type CatalogSet struct {
+	catalogs []*Catalog
+	mu       sync.RWMutex
+}
+
+//////
+// Helpers.
+//////
+
+// find returns the catalogs which have `errorCode`, as it is, or as an alias.
+func (s *CatalogSet) find(errorCode ErrorCode) []*Catalog {
+	var found []*Catalog
+
+	for _, c := range s.catalogs {
+		if _, _, ok := c.lookup(errorCode); ok {
+			found = append(found, c)
+		}
+	}
+
+	return found
+}
+
+// catalogNames returns the names of `catalogs`.
+func catalogNames(catalogs []*Catalog) string {
+	names := make([]string, 0, len(catalogs))
+
+	for _, c := range catalogs {
+		names = append(names, c.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+//////
+// Methods.
+//////
+
+// Add adds `c` to the set. It fails if there's already a catalog with the
+// same name, or if any of its codes, or aliases, is in another catalog.
+//
+// NOTE: Conflicts are also detected by `Get`, in case codes are set after the
+// catalog is added.
+func (s *CatalogSet) Add(c *Catalog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.catalogs {
+		if existing.Name == c.Name {
+			return fmt.Errorf("%w. Name: %s", ErrCatalogDuplicatedName, c.Name)
+		}
+	}
+
+	codes := c.Codes()
+
+	c.aliases.Range(func(alias, _ any) bool {
+		codes = append(codes, alias.(ErrorCode).String())
+
+		return true
+	})
+
+	for _, code := range codes {
+		if found := s.find(ErrorCode(code)); len(found) > 0 {
+			return fmt.Errorf(
+				"%w. Code: %s. Catalogs: %s",
+				ErrCatalogCodeConflict, code, catalogNames(append(found, c)),
+			)
+		}
+	}
+
+	s.catalogs = append(s.catalogs, c)
+
+	return nil
+}
+
+// Catalog returns the catalog named `name`, if any.
+func (s *CatalogSet) Catalog(name string) (*Catalog, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, c := range s.catalogs {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return nil, false
+}
+
+// Catalogs returns the catalogs, in the order they were added.
+func (s *CatalogSet) Catalogs() []*Catalog {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	catalogs := make([]*Catalog, len(s.catalogs))
+
+	copy(catalogs, s.catalogs)
+
+	return catalogs
+}
+
+// Get returns a custom error from the catalog which has `errorCode`, see
+// `Catalog.Get`. A fully qualified code (e.g.: "myapp:ERR_A1_B2") is looked up
+// in its catalog, otherwise, in all of them - as it is, prefixes included.
+func (s *CatalogSet) Get(errorCode string, opts ...Option) (*CustomError, error) {
+	if name, _, ok := strings.Cut(errorCode, NamespaceSeparator); ok {
+		c, found := s.Catalog(name)
+		if !found {
+			return nil, fmt.Errorf("%w. Code: %s", ErrCatalogErrorNotFound, errorCode)
+		}
+
+		return c.Get(errorCode, opts...)
+	}
+
+	eC, err := NewErrorCode(errorCode)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	found := s.find(eC)
+	s.mu.RUnlock()
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("%w. Code: %s", ErrCatalogErrorNotFound, eC)
+	case 1:
+		return found[0].Get(eC.String(), opts...)
+	default:
+		return nil, fmt.Errorf(
+			"%w. Code: %s. Catalogs: %s",
+			ErrCatalogCodeConflict, eC, catalogNames(found),
+		)
+	}
+}
+
+// Emit is like `Get`, but the error is emitted, see `Catalog.Emit`.
+func (s *CatalogSet) Emit(errorCode string, opts ...Option) (*CustomError, error) {
+	cE, err := s.Get(errorCode, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return finalize(cE), nil
+}
+
+// MustGet returns a custom error from the set, if not found, panics.
+func (s *CatalogSet) MustGet(errorCode string, opts ...Option) *CustomError {
+	customErr, err := s.Get(errorCode, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return customErr
+}
+
+//////
+// Factory.
+//////
+
+// NewCatalogSet creates a new catalog set with `catalogs`, see `Add`.
+func NewCatalogSet(catalogs ...*Catalog) (*CatalogSet, error) {
+	s := &CatalogSet{}
+
+	for _, c := range catalogs {
+		if err := s.Add(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// MustNewCatalogSet creates a new catalog set. If an error occurs, panics.
+func MustNewCatalogSet(catalogs ...*Catalog) *CatalogSet {
+	s, err := NewCatalogSet(catalogs...)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
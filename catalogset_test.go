@@ -0,0 +1,144 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCatalogSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		catalogs func() []*Catalog
+		wantErr  error
+	}{
+		{
+			name: "Should work",
+			catalogs: func() []*Catalog {
+				return []*Catalog{
+					MustNewCatalog("users").MustSet("ERR_A1_B2", "invalid user"),
+					MustNewCatalog("billing").MustSet("ERR_A1_B3", "invalid invoice"),
+				}
+			},
+		},
+		{
+			name: "Should work - prefixed catalogs don't collide",
+			catalogs: func() []*Catalog {
+				return []*Catalog{
+					MustNewCatalog("users", WithCodePrefix()).MustSet("ERR_A1_B2", "invalid user"),
+					MustNewCatalog("billing", WithCodePrefix()).MustSet("ERR_A1_B2", "invalid invoice"),
+				}
+			},
+		},
+		{
+			name: "Should fail - code conflict",
+			catalogs: func() []*Catalog {
+				return []*Catalog{
+					MustNewCatalog("users").MustSet("ERR_A1_B2", "invalid user"),
+					MustNewCatalog("billing").MustSet("ERR_A1_B2", "invalid invoice"),
+				}
+			},
+			wantErr: ErrCatalogCodeConflict,
+		},
+		{
+			name: "Should fail - duplicated name",
+			catalogs: func() []*Catalog {
+				return []*Catalog{MustNewCatalog("users"), MustNewCatalog("users")}
+			},
+			wantErr: ErrCatalogDuplicatedName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewCatalogSet(tt.catalogs()...)
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), err)
+				assert.Nil(t, s)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, s.Catalogs(), 2)
+		})
+	}
+}
+
+func TestCatalogSet_Get(t *testing.T) {
+	users := MustNewCatalog("users", WithCodePrefix()).MustSet("ERR_A1_B2", "invalid user")
+	billing := MustNewCatalog("billing", WithCodePrefix()).MustSet("ERR_A1_B2", "invalid invoice")
+	legacy := MustNewCatalog("legacy").MustSet("E1010", "invalid response")
+
+	s := MustNewCatalogSet(users, billing, legacy)
+
+	tests := []struct {
+		name        string
+		errorCode   string
+		want        string
+		wantCatalog *Catalog
+		wantErr     error
+	}{
+		{
+			name:        "Should get - fully qualified",
+			errorCode:   "billing:ERR_A1_B2",
+			want:        "BILLING_ERR_A1_B2: invalid invoice",
+			wantCatalog: billing,
+		},
+		{
+			name:        "Should get - prefixed",
+			errorCode:   "USERS_ERR_A1_B2",
+			want:        "USERS_ERR_A1_B2: invalid user",
+			wantCatalog: users,
+		},
+		{
+			name:        "Should get - unprefixed catalog",
+			errorCode:   "e1010",
+			want:        "invalid response",
+			wantCatalog: legacy,
+		},
+		{
+			name:      "Should fail - unknown catalog",
+			errorCode: "payments:ERR_A1_B2",
+			wantErr:   ErrCatalogErrorNotFound,
+		},
+		{
+			name:      "Should fail - not found",
+			errorCode: "ERR_A1_B2",
+			wantErr:   ErrCatalogErrorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cE, err := s.Get(tt.errorCode)
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), err)
+
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.EqualError(t, cE, tt.want)
+				assert.Same(t, tt.wantCatalog, cE.Catalog())
+			}
+		})
+	}
+
+	// Codes set after the catalog is added are detected at lookup.
+	_ = s.Add(MustNewCatalog("late"))
+
+	late, _ := s.Catalog("late")
+
+	late.MustSet("E1010", "late response")
+
+	_, err := s.Get("E1010")
+
+	assert.True(t, errors.Is(err, ErrCatalogCodeConflict), err)
+	assert.ErrorContains(t, err, "Catalogs: legacy, late")
+}
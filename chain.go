@@ -0,0 +1,104 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+//////
+// Helpers.
+//////
+
+// walkAll calls `fn` for each error in the chain of `err`, outermost first,
+// following both `Unwrap() error`, and `Unwrap() []error`. If `fn` returns
+// false, walking stops.
+func walkAll(err error, fn func(err error) bool) bool {
+	for err != nil {
+		if !fn(err) {
+			return false
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, e := range x.Unwrap() {
+				if !walkAll(e, fn) {
+					return false
+				}
+			}
+
+			return true
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		default:
+			return true
+		}
+	}
+
+	return true
+}
+
+// walk is like `walkAll` but only calls `fn` for `CustomError`s.
+func walk(err error, fn func(cE *CustomError) bool) bool {
+	return walkAll(err, func(err error) bool {
+		if cE, ok := err.(*CustomError); ok && cE != nil {
+			return fn(cE)
+		}
+
+		return true
+	})
+}
+
+//////
+// Exported functionalities.
+//////
+
+// AllFields returns the fields of every `CustomError` in the chain of `err`
+// merged. On conflict, the outermost error wins - it's the closest to the
+// caller, thus the most specific. For errors wrapping multiple errors
+// (`Unwrap() []error`), earlier branches win over later ones.
+func AllFields(err error) Fields {
+	var list []Field
+
+	walk(err, func(cE *CustomError) bool {
+		cE.Fields.Range(func(key string, value any) bool {
+			for _, field := range list {
+				if field.Key == key {
+					return true
+				}
+			}
+
+			list = append(list, Field{Key: key, Value: value})
+
+			return true
+		})
+
+		return true
+	})
+
+	return Fields{list: list}
+}
+
+// AllTags returns the tags of every `CustomError` in the chain of `err`.
+func AllTags(err error) Set {
+	var tags Set
+
+	walk(err, func(cE *CustomError) bool {
+		tags = tags.Merge(cE.Tags)
+
+		return true
+	})
+
+	return tags
+}
+
+// HasTag returns true if any `CustomError` in the chain of `err` has `tag`.
+func HasTag(err error, tag string) bool {
+	found := false
+
+	walk(err, func(cE *CustomError) bool {
+		found = cE.Tags.Contains(tag)
+
+		return !found
+	})
+
+	return found
+}
@@ -0,0 +1,88 @@
+package customerror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// multiError wraps multiple errors (`Unwrap() []error`).
+type multiError []error
+
+func (m multiError) Error() string {
+	return fmt.Sprintf("%d errors", len(m))
+}
+
+func (m multiError) Unwrap() []error {
+	return m
+}
+
+func TestAllFields(t *testing.T) {
+	innermost := New("innermost", WithField("key1", "innermost"), WithField("key4", "innermost"), WithTag("db"))
+	left := New("left", WithField("key2", "left"), WithTag("left"), WithError(innermost))
+	right := New("right", WithField("key2", "right"), WithField("key3", "right"), WithTag("right"))
+	outer := New("outer", WithField("key1", "outer"), WithTag("api"), WithError(multiError{left, right}))
+	wrapped := fmt.Errorf("wrapped: %w", outer)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantFields map[string]any
+		wantKeys   []string
+		wantTags   []string
+	}{
+		{
+			name: "Should work - outer wins, earlier branches win",
+			err:  wrapped,
+			wantFields: map[string]any{
+				"key1": "outer",
+				"key2": "left",
+				"key3": "right",
+				"key4": "innermost",
+			},
+			wantKeys: []string{"key1", "key2", "key4", "key3"},
+			wantTags: []string{"api", "db", "left", "right"},
+		},
+		{
+			name:       "Should work - not a custom error",
+			err:        errors.New("some error"),
+			wantFields: map[string]any{},
+			wantKeys:   []string{},
+			wantTags:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := AllFields(tt.err)
+
+			assert.Equal(t, tt.wantFields, fields.ToMap())
+			assert.Equal(t, tt.wantKeys, fields.Keys())
+			assert.Equal(t, tt.wantTags, AllTags(tt.err).Values())
+		})
+	}
+}
+
+func TestHasTag(t *testing.T) {
+	inner := New("inner", WithTag("db"))
+	outer := New("outer", WithTag("api"), WithError(multiError{errors.New("some error"), inner}))
+
+	assert.True(t, HasTag(outer, "api"))
+	assert.True(t, HasTag(fmt.Errorf("wrapped: %w", outer), "db"))
+	assert.False(t, HasTag(outer, "cache"))
+	assert.False(t, HasTag(errors.New("some error"), "db"))
+}
+
+func TestWithMergedChain(t *testing.T) {
+	inner := New("inner", WithField("key1", "inner"), WithField("key2", "inner"), WithTag("db"))
+
+	b, err := json.Marshal(New("outer", WithField("key1", "outer"), WithTag("api"), WithError(inner)))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"key1":"outer","message":"outer","severity":"error","tags":["api"]}`, string(b))
+
+	b, err = json.Marshal(New("outer", WithField("key1", "outer"), WithTag("api"), WithError(inner), WithMergedChain()))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"key1":"outer","key2":"inner","message":"outer","severity":"error","tags":["api","db"]}`, string(b))
+}
@@ -0,0 +1,78 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Command customerror-docgen generates the documentation of a catalog file,
+// see `customerror.CatalogFile`.
+//
+// Usage:
+//
+//	customerror-docgen [-format markdown|html|openapi] [-o file] [-prefix] catalog.json
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/thalesfsp/customerror"
+	"github.com/thalesfsp/customerror/docgen"
+)
+
+// run runs the command with `args`, writing to `stdout`, unless `-o` is set.
+func run(args []string, stdout io.Writer) error {
+	flagSet := flag.NewFlagSet("customerror-docgen", flag.ContinueOnError)
+
+	format := flagSet.String("format", string(docgen.FormatMarkdown), "output format: markdown, html, or openapi")
+	output := flagSet.String("o", "", "output file (default stdout)")
+	prefix := flagSet.Bool("prefix", false, "prefix codes with the catalog name, see customerror.WithCodePrefix")
+
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if flagSet.NArg() != 1 {
+		return fmt.Errorf("expected one catalog file, got %d arguments", flagSet.NArg())
+	}
+
+	f, err := os.Open(flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	var opts []customerror.CatalogOption
+
+	if *prefix {
+		opts = append(opts, customerror.WithCodePrefix())
+	}
+
+	c, err := customerror.ReadCatalog(f, opts...)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return docgen.Render(stdout, c, docgen.Format(*format))
+	}
+
+	// Rendered first, so a failure doesn't truncate an existing output file.
+	var buf bytes.Buffer
+
+	if err := docgen.Render(&buf, c, docgen.Format(*format)); err != nil {
+		return err
+	}
+
+	return os.WriteFile(*output, buf.Bytes(), 0o666)
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCatalog = "../../docgen/testdata/catalog.json"
+
+func TestRun(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "errors.html")
+
+	tests := []struct {
+		name     string
+		args     []string
+		contains string
+		wantErr  string
+	}{
+		{
+			name:     "Should render markdown",
+			args:     []string{testCatalog},
+			contains: "# myapp errors",
+		},
+		{
+			name:     "Should render openapi, prefixed",
+			args:     []string{"-format", "openapi", "-prefix", testCatalog},
+			contains: `"MYAPP_E1010"`,
+		},
+		{
+			name: "Should write to file",
+			args: []string{"-format", "html", "-o", output, testCatalog},
+		},
+		{
+			name:    "Should fail - no catalog",
+			args:    []string{},
+			wantErr: "expected one catalog file",
+		},
+		{
+			name:    "Should fail - unknown format",
+			args:    []string{"-format", "pdf", testCatalog},
+			wantErr: "Format: pdf",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+
+			err := run(tt.args, &stdout)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Contains(t, stdout.String(), tt.contains)
+		})
+	}
+
+	content, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "<h1>myapp errors</h1>")
+}
+
+func TestRun_invalidFormatKeepsOutput(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "errors.md")
+
+	assert.NoError(t, os.WriteFile(output, []byte("# existing"), 0o600))
+
+	err := run([]string{"-format", "pdf", "-o", output, testCatalog}, &bytes.Buffer{})
+
+	assert.ErrorContains(t, err, "Format: pdf")
+
+	content, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "# existing", string(content))
+}
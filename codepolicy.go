@@ -0,0 +1,57 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"fmt"
+	"regexp"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// Built-in code policies.
+var (
+	// NumericCodePolicy only allows "E" followed by 1 to 8 digits, e.g.:
+	// E1010.
+	NumericCodePolicy = RegexCodePolicy(regexp.MustCompile(`^E\d{1,8}$`))
+
+	// ScreamingSnakeCodePolicy only allows upper cased words of letters, and
+	// numbers, starting with a letter, separated by single underscores, e.g.:
+	// INVALID_REQUEST.
+	ScreamingSnakeCodePolicy = RegexCodePolicy(regexp.MustCompile(`^[A-Z][A-Z\d]*(_[A-Z\d]+)*$`))
+
+	// StrictCodePolicy only allows the "ERR_{letter_number}_{letter_number}"
+	// format, with the optional prefix, and suffix, e.g.: ERR_A1_B2, or
+	// 1A_ERR_A1_B2_3C. See `ErrorCodeRegex`.
+	StrictCodePolicy = RegexCodePolicy(regexp.MustCompile(`^(\d?[A-Za-z]_)?ERR_[A-Za-z]\d_[A-Za-z]\d(_\d[A-Za-z])?$`))
+)
+
+// CodePolicy validates the error codes of a catalog, see `WithCodePolicy`.
+// Codes are upper cased before validated, and validated without the catalog
+// prefix, if any.
+type CodePolicy func(code ErrorCode) error
+
+//////
+// Exported functionalities.
+//////
+
+// DefaultCodePolicy validates codes against `ErrorCodeRegex`. It's the
+// default.
+func DefaultCodePolicy(code ErrorCode) error {
+	return code.Validate()
+}
+
+// RegexCodePolicy only allows codes matching `re`.
+func RegexCodePolicy(re *regexp.Regexp) CodePolicy {
+	return func(code ErrorCode) error {
+		if !re.MatchString(code.String()) {
+			return fmt.Errorf("%w. Code: %s. Pattern: %s", ErrErrorCodeInvalidCode, code, re)
+		}
+
+		return nil
+	}
+}
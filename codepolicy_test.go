@@ -0,0 +1,114 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCode_Validate(t *testing.T) {
+	accepted := []string{"1A_ERR_A1_B2", "1A_ERR_A1_B2_3C", "ERR_A1_B2", "ERR_A1_B2_3C", "E12345678", "AbCd123", "INVALID_REQUEST_BODY"}
+	rejected := []string{"", "!!!bad code", "bad code", "INVALID__REQUEST", "_INVALID", "INVALID_", "ERR-A1-B2", "E1010!"}
+
+	for _, code := range accepted {
+		assert.NoError(t, ErrorCode(code).Validate(), code)
+	}
+
+	for _, code := range rejected {
+		assert.ErrorIs(t, ErrorCode(code).Validate(), ErrErrorCodeInvalidCode, code)
+	}
+}
+
+func TestCodePolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   CodePolicy
+		accepted []string
+		rejected []string
+	}{
+		{
+			name:     "DefaultCodePolicy",
+			policy:   DefaultCodePolicy,
+			accepted: []string{"ERR_A1_B2", "E1010", "INVALID_REQUEST", "AbCd123"},
+			rejected: []string{"!!!bad code", "INVALID-REQUEST"},
+		},
+		{
+			name:     "StrictCodePolicy",
+			policy:   StrictCodePolicy,
+			accepted: []string{"ERR_A1_B2", "1A_ERR_A1_B2", "ERR_A1_B2_3C"},
+			rejected: []string{"E1010", "INVALID_REQUEST", "ERR_AA_B2", "ERR_A1_B2_C3"},
+		},
+		{
+			name:     "NumericCodePolicy",
+			policy:   NumericCodePolicy,
+			accepted: []string{"E1", "E1010", "E12345678"},
+			rejected: []string{"E", "E123456789", "ERR_A1_B2", "1010"},
+		},
+		{
+			name:     "ScreamingSnakeCodePolicy",
+			policy:   ScreamingSnakeCodePolicy,
+			accepted: []string{"INVALID", "INVALID_REQUEST", "ERR_A1_B2", "E1010"},
+			rejected: []string{"invalid_request", "1A_ERR_A1_B2", "INVALID__REQUEST", "INVALID_"},
+		},
+		{
+			name:     "RegexCodePolicy",
+			policy:   RegexCodePolicy(regexp.MustCompile(`^USR_\d{3}$`)),
+			accepted: []string{"USR_001"},
+			rejected: []string{"USR_1", "ERR_A1_B2"},
+		},
+		{
+			name: "Custom func",
+			policy: func(code ErrorCode) error {
+				if !strings.HasPrefix(code.String(), "MYAPP") {
+					return ErrErrorCodeInvalidCode
+				}
+
+				return nil
+			},
+			accepted: []string{"MYAPP_INVALID"},
+			rejected: []string{"INVALID"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, code := range tt.accepted {
+				assert.NoError(t, tt.policy(ErrorCode(code)), code)
+			}
+
+			for _, code := range tt.rejected {
+				assert.True(t, errors.Is(tt.policy(ErrorCode(code)), ErrErrorCodeInvalidCode), code)
+			}
+		})
+	}
+}
+
+func TestCatalog_WithCodePolicy(t *testing.T) {
+	catalog := MustNewCatalog("myapp", WithCodePolicy(StrictCodePolicy), WithCodePrefix())
+
+	code, err := catalog.Set("err_a1_b2", "invalid response")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "MYAPP_ERR_A1_B2", code)
+
+	_, err = catalog.Set("E1010", "invalid response")
+
+	assert.ErrorIs(t, err, ErrErrorCodeInvalidCode)
+
+	_, err = catalog.Get("E1010")
+
+	assert.ErrorIs(t, err, ErrErrorCodeInvalidCode)
+
+	assert.NoError(t, catalog.Validate())
+
+	// Catalogs not created with `NewCatalog` use the default policy.
+	_, err = (&Catalog{Name: "myapp", ErrorCodeErrorMap: MustNewCatalog("myapp").ErrorCodeErrorMap}).Set("!!!", "invalid response")
+
+	assert.ErrorIs(t, err, ErrErrorCodeInvalidCode)
+}
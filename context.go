@@ -0,0 +1,139 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"context"
+	"sync"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// Built-in context keys.
+const (
+	contextKeyRequestID contextKey = "requestID"
+	contextKeyTenant    contextKey = "tenant"
+	contextKeyUser      contextKey = "user"
+)
+
+// Built-in field keys, set from the context by the built-in extractors.
+const (
+	// FieldRequestID is the request ID, see `ContextWithRequestID`.
+	FieldRequestID FieldKey[string] = "requestID"
+
+	// FieldTenant is the tenant, see `ContextWithTenant`.
+	FieldTenant FieldKey[string] = "tenant"
+
+	// FieldUser is the user, see `ContextWithUser`.
+	FieldUser FieldKey[string] = "user"
+)
+
+var (
+	contextExtractorsMu sync.RWMutex
+	contextExtractors   = []contextExtractorEntry{
+		{key: FieldRequestID.String(), extractor: ContextValueExtractor(contextKeyRequestID)},
+		{key: FieldTenant.String(), extractor: ContextValueExtractor(contextKeyTenant)},
+		{key: FieldUser.String(), extractor: ContextValueExtractor(contextKeyUser)},
+	}
+)
+
+type (
+	// ContextExtractor extracts a value from a context. It returns false if
+	// there's nothing to extract.
+	ContextExtractor func(ctx context.Context) (any, bool)
+
+	// contextExtractorEntry is a registered extractor, and the field key it
+	// sets.
+	contextExtractorEntry struct {
+		extractor ContextExtractor
+		key       string
+	}
+
+	// contextKey prevents collisions with context keys defined in other
+	// packages.
+	contextKey string
+)
+
+//////
+// Exported functionalities.
+//////
+
+// ContextValueExtractor returns an extractor for values stored in the context
+// under `key`, e.g.: the request ID key of a HTTP framework. Nil, and empty
+// strings aren't extracted.
+func ContextValueExtractor(key any) ContextExtractor {
+	return func(ctx context.Context) (any, bool) {
+		value := ctx.Value(key)
+
+		if s, ok := value.(string); ok && s == "" {
+			return nil, false
+		}
+
+		return value, value != nil
+	}
+}
+
+// RegisterContextExtractor registers an extractor which sets the field `key`
+// for errors created with a context, see `WithContext`. Registering the same
+// key again replaces the extractor. Extractors run in registration order.
+func RegisterContextExtractor[K ~string](key K, extractor ContextExtractor) {
+	contextExtractorsMu.Lock()
+	defer contextExtractorsMu.Unlock()
+
+	// Copy-on-write: `WithContext` calls a snapshot of the extractors.
+	final := make([]contextExtractorEntry, 0, len(contextExtractors)+1)
+	replaced := false
+
+	for _, entry := range contextExtractors {
+		if entry.key == string(key) {
+			entry.extractor = extractor
+			replaced = true
+		}
+
+		final = append(final, entry)
+	}
+
+	if !replaced {
+		final = append(final, contextExtractorEntry{
+			extractor: extractor,
+			key:       string(key),
+		})
+	}
+
+	contextExtractors = final
+}
+
+// ContextWithRequestID returns a copy of `ctx` with the request ID.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, contextKeyRequestID, requestID)
+}
+
+// ContextWithTenant returns a copy of `ctx` with the tenant.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, contextKeyTenant, tenant)
+}
+
+// ContextWithUser returns a copy of `ctx` with the user.
+func ContextWithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, contextKeyUser, user)
+}
+
+//////
+// Factory.
+//////
+
+// NewCtx is like `New`, but fields are also extracted from `ctx`, see
+// `WithContext`.
+func NewCtx(ctx context.Context, message string, opts ...Option) error {
+	return New(message, prependOptions(opts, WithContext(ctx))...)
+}
+
+// FactoryCtx is like `Factory`, but fields are also extracted from `ctx`, see
+// `WithContext`.
+func FactoryCtx(ctx context.Context, message string, opts ...Option) *CustomError {
+	return Factory(message, prependOptions(opts, WithContext(ctx))...)
+}
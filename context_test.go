@@ -0,0 +1,104 @@
+package customerror
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testContextKey struct{}
+
+func TestWithContext(t *testing.T) {
+	// Restore the registry afterwards.
+	contextExtractorsMu.RLock()
+	original := append([]contextExtractorEntry{}, contextExtractors...)
+	contextExtractorsMu.RUnlock()
+
+	t.Cleanup(func() {
+		contextExtractorsMu.Lock()
+		contextExtractors = original
+		contextExtractorsMu.Unlock()
+	})
+
+	RegisterContextExtractor("traceID", ContextValueExtractor(testContextKey{}))
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	ctx = ContextWithTenant(ctx, "acme")
+	ctx = ContextWithUser(ctx, "john")
+	ctx = context.WithValue(ctx, testContextKey{}, "trace-1")
+
+	tests := []struct {
+		name string
+		err  error
+		want map[string]any
+	}{
+		{
+			name: "Should work - NewCtx",
+			err:  NewCtx(ctx, "some error"),
+			want: map[string]any{"requestID": "req-1", "tenant": "acme", "user": "john", "traceID": "trace-1"},
+		},
+		{
+			name: "Should work - FactoryCtx",
+			err:  FactoryCtx(ctx, "some error"),
+			want: map[string]any{"requestID": "req-1", "tenant": "acme", "user": "john", "traceID": "trace-1"},
+		},
+		{
+			name: "Should work - factory methods",
+			err:  Factory("host").NewMissingError(WithContext(ctx)),
+			want: map[string]any{"requestID": "req-1", "tenant": "acme", "user": "john", "traceID": "trace-1"},
+		},
+		{
+			name: "Should work - explicit fields aren't overridden",
+			err:  NewCtx(ctx, "some error", WithTypedField(FieldUser, "jane")),
+			want: map[string]any{"requestID": "req-1", "tenant": "acme", "user": "jane", "traceID": "trace-1"},
+		},
+		{
+			name: "Should work - nothing in the context",
+			err:  NewCtx(context.Background(), "some error"),
+			want: map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AllFields(tt.err).ToMap())
+		})
+	}
+
+	requestID, ok := FieldRequestID.Get(NewCtx(ctx, "some error"))
+	assert.True(t, ok)
+	assert.Equal(t, "req-1", requestID)
+}
+
+func TestWithContext_reentrant(t *testing.T) {
+	// Restore the registry afterwards.
+	contextExtractorsMu.RLock()
+	original := contextExtractors
+	contextExtractorsMu.RUnlock()
+
+	t.Cleanup(func() {
+		contextExtractorsMu.Lock()
+		contextExtractors = original
+		contextExtractorsMu.Unlock()
+	})
+
+	// Registers an extractor, and creates an error with a context - it'd
+	// deadlock if extractors were called locked.
+	nested := false
+
+	RegisterContextExtractor("nested", func(ctx context.Context) (any, bool) {
+		if nested {
+			return nil, false
+		}
+
+		nested = true
+
+		RegisterContextExtractor("other", ContextValueExtractor(testContextKey{}))
+
+		return NewCtx(ctx, "nested error").Error(), true
+	})
+
+	err := NewCtx(ContextWithUser(context.Background(), "john"), "some error")
+
+	assert.Equal(t, map[string]any{"nested": "nested error. Fields: user=john", "user": "john"}, AllFields(err).ToMap())
+}
@@ -7,30 +7,63 @@ package customerror
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"strings"
 	"sync"
 
-	"github.com/emirpasic/gods/sets/treeset"
 	"github.com/go-playground/validator/v10"
 )
 
+//////
+// Consts, vars, and types.
+//////
+
+// Singleton.
+var (
+	validatorOnce      sync.Once
+	singletonValidator *validator.Validate
+)
+
 //////
 // Helpers.
 //////
 
+// getValidator returns the validator singleton. Creating a validator is
+// expensive (it parses, and caches struct tags), and it's safe for concurrent
+// use, so it's created once, and reused.
+func getValidator() *validator.Validate {
+	validatorOnce.Do(func() {
+		singletonValidator = validator.New()
+	})
+
+	return singletonValidator
+}
+
 // Copy src to target.
 func Copy(src, target *CustomError) *CustomError {
+	if src.catalog != nil {
+		target.catalog = src.catalog
+	}
+
 	if src.Code != "" {
 		target.Code = src.Code
 	}
 
+	if src.deprecation != nil {
+		target.deprecation = src.deprecation
+	}
+
 	if src.Err != nil {
 		target.Err = src.Err
 	}
 
+	if src.errorType != "" {
+		target.errorType = src.errorType
+	}
+
 	if src.language != "" {
 		target.language = src.language
 	}
@@ -39,6 +72,22 @@ func Copy(src, target *CustomError) *CustomError {
 		target.Message = src.Message
 	}
 
+	if src.Retry != nil {
+		target.Retry = src.Retry
+	}
+
+	target.retry = target.retry.merge(src.retry)
+
+	// Set severities win over defaults, which are derived after merging.
+	if src.Severity != 0 {
+		if !src.severityDefault {
+			target.Severity = src.Severity
+			target.severityDefault = false
+		} else if target.Severity == 0 {
+			target.severityDefault = true
+		}
+	}
+
 	if src.StatusCode != 0 {
 		target.StatusCode = src.StatusCode
 	}
@@ -47,125 +96,61 @@ func Copy(src, target *CustomError) *CustomError {
 		target.ignore = src.ignore
 	}
 
-	// Merge the language messages.
-	if src.LanguageMessageMap != nil {
-		if target.LanguageMessageMap == nil {
-			target.LanguageMessageMap = &sync.Map{}
-		}
-
-		finalLanguageMessageMap := &sync.Map{}
-
-		src.LanguageMessageMap.Range(func(key, value interface{}) bool {
-			finalLanguageMessageMap.Store(key, value)
-
-			return true
-		})
-
-		target.LanguageMessageMap.Range(func(key, value interface{}) bool {
-			finalLanguageMessageMap.Store(key, value)
-
-			return true
-		})
-
-		target.LanguageMessageMap = finalLanguageMessageMap
+	if src.mergeChain {
+		target.mergeChain = src.mergeChain
 	}
 
-	// Merge fields.
-	if src.Fields != nil {
-		if target.Fields == nil {
-			target.Fields = &sync.Map{}
-		}
-
-		finalFields := &sync.Map{}
-
-		src.Fields.Range(func(key, value interface{}) bool {
-			finalFields.Store(key, value)
-
-			return true
-		})
-
-		target.Fields.Range(func(key, value interface{}) bool {
-			finalFields.Store(key, value)
-
-			return true
-		})
-
-		target.Fields = finalFields
+	if src.renderMode != RenderPublic {
+		target.renderMode = src.renderMode
 	}
 
-	// Merge the tags.
-	if src.Tags != nil {
-		if target.Tags == nil {
-			target.Tags = &Set{treeset.NewWithStringComparator()}
-		}
-
-		src.Tags.Each(func(index int, value interface{}) {
-			target.Tags.Add(value)
-		})
+	if len(src.stack) > 0 {
+		target.stack = src.stack
 	}
 
-	return target
-}
-
-// Process fields and add them to the error message.
-func processFields(errMsg string, fields *sync.Map) string {
-	if fields != nil {
-		errMsg = fmt.Sprintf("%s. Fields:", errMsg)
+	// Target wins: it was translated from the original message.
+	if target.untranslated == "" {
+		target.untranslated = src.untranslated
+	}
 
-		fields.Range(func(k, v interface{}) bool {
-			errMsg = fmt.Sprintf("%s %s=%v,", errMsg, k, v)
+	// Merge the language messages, fields, and tags. Target wins. They are
+	// copy-on-write, so most of the time it's just sharing.
+	target.LanguageMessageMap = src.LanguageMessageMap.Merge(target.LanguageMessageMap)
 
-			return true
-		})
+	target.LanguageErrorTypeMap = mergeTemplates(src.LanguageErrorTypeMap, target.LanguageErrorTypeMap)
 
-		errMsg = strings.TrimSuffix(errMsg, ",")
-	}
+	target.Fields = src.Fields.Merge(target.Fields)
 
-	return errMsg
-}
+	target.Tags = src.Tags.Merge(target.Tags)
 
-// mapToSyncMap converts a map to a sync.Map.
-func mapToSyncMap(m map[string]interface{}) *sync.Map {
-	sm := &sync.Map{}
+	target.fingerprintFields = src.fingerprintFields.Merge(target.fingerprintFields)
 
-	for k, v := range m {
-		sm.Store(k, v)
+	if target.severityDefault {
+		target.Severity = target.defaultSeverity()
 	}
 
-	return sm
+	return target
 }
 
-// syncMapToMap converts a sync.Map to a map.
-func syncMapToMap(sm *sync.Map) map[string]interface{} {
-	m := make(map[string]interface{})
-
-	if sm != nil {
-		sm.Range(func(k, v interface{}) bool {
-			if str, ok := k.(string); ok {
-				m[str] = v
-			}
-
-			return true
-		})
+// Process fields and add them to the error message. Sensitive values are
+// rendered according to `mode`.
+func processFields(errMsg string, fields Fields, mode RenderMode) string {
+	if fields.Len() == 0 {
+		return errMsg
 	}
 
-	return m
-}
+	var sb strings.Builder
 
-// Set is a wrapper around the treeset.Set.
-type Set struct {
-	*treeset.Set
-}
+	sb.WriteString(errMsg)
+	sb.WriteString(". Fields:")
 
-// Implement Stringer interface.
-func (s *Set) String() string {
-	items := []string{}
+	fields.Range(func(k string, v any) bool {
+		fmt.Fprintf(&sb, " %s=%v,", k, renderValue(mode, k, v))
 
-	s.Each(func(index int, value interface{}) {
-		items = append(items, fmt.Sprintf("%v", value))
+		return true
 	})
 
-	return strings.Join(items, ", ")
+	return strings.TrimSuffix(sb.String(), ",")
 }
 
 // CustomError is the base block to create custom errors. It provides context -
@@ -179,7 +164,7 @@ type CustomError struct {
 	Err error `json:"-"`
 
 	// Field enhances the error message with more structured information.
-	Fields *sync.Map `json:"fields,omitempty"`
+	Fields Fields `json:"fields,omitempty"`
 
 	// Human readable message. Minimum length: 3.
 	Message string `json:"message" validate:"required,gte=3"`
@@ -188,20 +173,60 @@ type CustomError struct {
 	LanguageMessageMap LanguageMessageMap `json:"languageMessageMap"`
 
 	// LanguageErrorTypeMap is a map of language prefixes to templates such
-	// as "missing %s", "%s required", "%s invalid", etc.
+	// as "missing %s", "%s required", "%s invalid", etc. They win over the
+	// global ones, see `WithErrorTypeTemplate`, and `Template`.
 	LanguageErrorTypeMap LanguageErrorMap `json:"languageErrorTypeMap"`
 
+	// Retry is the retry metadata. If set, it wins over the retry options
+	// (`WithRetryable`, `WithRetryAfter`, and `WithTemporary`), and the status
+	// code, see `IsRetryable`.
+	Retry *Retry `json:"-"`
+
+	// Severity of the error. If not set, it's derived from the status code.
+	Severity Severity `json:"severity,omitempty"`
+
 	// StatusCode is a valid HTTP status code, e.g.: 404.
 	StatusCode int `json:"-" validate:"omitempty,gte=100,lte=511"`
 
 	// Tags is a SET of tags which helps to categorize the error.
-	Tags *Set `json:"tags,omitempty"`
+	Tags Set `json:"tags,omitempty"`
+
+	// Catalog the error comes from, if any.
+	catalog *Catalog
+
+	// Deprecation metadata, if deprecated.
+	deprecation *Deprecation
+
+	// Type of the error, e.g.: `NotFound`.
+	errorType ErrorType
+
+	// Keys of the fields included in the fingerprint.
+	fingerprintFields Set
 
 	// If set to true, the error will be ignored (return nil).
 	ignore bool `json:"-"`
 
 	// Language to be use for the message and prefix.
 	language Language
+
+	// If set to true, JSON includes fields, and tags of the whole chain.
+	mergeChain bool
+
+	// Determines how the error is rendered.
+	renderMode RenderMode
+
+	// Retry metadata set by options, see `WithRetryable`.
+	retry retryOverrides
+
+	// If set to true, the severity wasn't set, but derived, see
+	// `defaultSeverity`.
+	severityDefault bool
+
+	// Program counters of the stack trace, if captured.
+	stack []uintptr
+
+	// Message before translated, used by `Fingerprint`.
+	untranslated string
 }
 
 //////
@@ -225,11 +250,11 @@ func (cE *CustomError) Error() string {
 		errMsg = fmt.Errorf("%s. Original Error: %w", errMsg, cE.Err).Error()
 	}
 
-	if cE.Tags != nil {
+	if !cE.Tags.Empty() {
 		errMsg = fmt.Sprintf("%s. Tags: %s", errMsg, cE.Tags.String())
 	}
 
-	errMsg = processFields(errMsg, cE.Fields)
+	errMsg = processFields(errMsg, cE.Fields, cE.renderMode)
 
 	return errMsg
 }
@@ -249,51 +274,52 @@ func (cE *CustomError) Unwrap() error {
 	return cE.Err
 }
 
+// Format implements the fmt.Formatter interface. `%s`, and `%v` are the same
+// as `Error`, `%q` is it quoted, and `%+v` also includes the severity, and the
+// stack trace, if captured.
+func (cE *CustomError) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		errMsg := cE.Error()
+
+		if cE.Severity != 0 {
+			errMsg = fmt.Sprintf("%s. Severity: %s", errMsg, cE.Severity)
+		}
+
+		_, _ = io.WriteString(f, errMsg)
+
+		for _, frame := range cE.StackTrace() {
+			fmt.Fprintf(f, "\n%s", frame)
+		}
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", cE.Error())
+	default:
+		_, _ = io.WriteString(f, cE.Error())
+	}
+}
+
 //////
 // Implementing the json.Marshaler interface.
 //////
 
-// MarshalJSON implements the json.Marshaler interface.
+// MarshalJSON implements the json.Marshaler interface. By default, it's the
+// public rendering, safe to be sent to clients: just the (translated) message,
+// code, tags, and safe fields. The wrapped error - which may expose internals
+// such as database, or driver errors - is only included, as "cause", if the
+// error is rendered internally, see `RenderMode`. Fields are flattened, but
+// can't override the other members. Its schema is `JSONSchema`.
 //
 // SEE https://gist.github.com/thalesfsp/3a1252530750e2370345a2418721ff54
 func (cE *CustomError) MarshalJSON() ([]byte, error) {
-	// Define a temporary map that matches the desired JSON format.
-	temp := make(map[string]interface{})
-
-	// Populate the temporary map.
-	temp["message"] = cE.JustError()
-
-	if cE.Code != "" {
-		temp["code"] = cE.Code
-	}
-
-	if cE.Tags != nil && !cE.Tags.Empty() {
-		temp["tags"] = cE.Tags
-	}
-
-	if cE.Fields != nil {
-		// Convert the sync.Map to a regular map so that we can iterate over its keys.
-		fields := syncMapToMap(cE.Fields)
-
-		// Populate the fields of the temporary map.
-		if len(fields) > 0 {
-			for k, v := range fields {
-				if k != "" && v != nil {
-					temp[k] = v
-				}
-			}
-		}
-	}
-
-	// Serialize the temporary map to JSON.
-	return json.Marshal(temp)
+	return json.Marshal(cE.wireFormat(reservedMembers))
 }
 
 //////
 // Error message formatting.
 //////
 
-// JustError returns the error message without any additional information.
+// JustError returns the error message, and the wrapped error without any
+// additional information.
 func (cE *CustomError) JustError() string {
 	errMsg := cE.Message
 
@@ -328,15 +354,18 @@ func (cE *CustomError) APIError() string {
 		errMsg = fmt.Errorf("%s. Original Error: %w", errMsg, cE.Err).Error()
 	}
 
-	if cE.Tags != nil {
+	if !cE.Tags.Empty() {
 		errMsg = fmt.Sprintf("%s. Tags: %s", errMsg, cE.Tags.String())
 	}
 
-	errMsg = processFields(errMsg, cE.Fields)
+	errMsg = processFields(errMsg, cE.Fields, cE.renderMode)
 
 	return errMsg
 }
 
+// X builds a copy of the error, of type `errorType`, with `opts` applied. If
+// translated, the message is prefixed with the template of the language, see
+// `Template`. Factory methods use it.
 func (cE *CustomError) X(errorType string, opts ...Option) *CustomError {
 	if cE == nil {
 		return nil
@@ -346,24 +375,26 @@ func (cE *CustomError) X(errorType string, opts ...Option) *CustomError {
 
 	finalCE = Copy(cE, finalCE)
 
+	finalCE.errorType = ErrorType(errorType)
+
 	// Apply options.
 	for _, opt := range opts {
 		opt(finalCE)
 	}
 
 	if finalCE.language != "" {
-		template, err := GetTemplate(string(finalCE.language), string(FailedTo))
+		template, err := finalCE.template(finalCE.language, ErrorType(errorType))
 		if err != nil {
-			template2, err := GetTemplate(finalCE.language.GetRoot(), errorType)
-			if err != nil {
-				panic(err)
-			}
-
-			template = template2
+			panic(err)
 		}
 
 		finalCE.Message = fmt.Sprintf(template, finalCE.Message)
 
+		// Same as it would be without translation.
+		if englishTemplate, err := finalCE.template(English, ErrorType(errorType)); err == nil {
+			finalCE.untranslated = fmt.Sprintf(englishTemplate, finalCE.untranslated)
+		}
+
 		return finalCE
 	}
 
@@ -382,15 +413,13 @@ func (cE *CustomError) X(errorType string, opts ...Option) *CustomError {
 //
 // NOTE: Status code can be redefined, call `SetStatusCode`.
 func (cE *CustomError) NewFailedToError(opts ...Option) error {
-	finalCE := cE.X(string(FailedTo), opts...)
+	finalCE := cE.X(FailedTo.String(), opts...)
 
 	if finalCE.language == "" {
-		finalCE = Copy(NewFailedToError(finalCE.Message, opts...).(*CustomError), finalCE)
-
-		return finalCE
+		finalCE = Copy(newFailedToError(finalCE.Message, finalCE.templateOptions(opts)...), finalCE)
 	}
 
-	return finalCE
+	return emit(finalCE)
 }
 
 // NewInvalidError is the building block for errors usually thrown when
@@ -401,15 +430,13 @@ func (cE *CustomError) NewFailedToError(opts ...Option) error {
 //
 // NOTE: Status code can be redefined, call `SetStatusCode`.
 func (cE *CustomError) NewInvalidError(opts ...Option) error {
-	finalCE := cE.X(string(Invalid), opts...)
+	finalCE := cE.X(Invalid.String(), opts...)
 
 	if finalCE.language == "" {
-		finalCE = Copy(NewInvalidError(finalCE.Message, opts...).(*CustomError), finalCE)
-
-		return finalCE
+		finalCE = Copy(newInvalidError(finalCE.Message, finalCE.templateOptions(opts)...), finalCE)
 	}
 
-	return finalCE
+	return emit(finalCE)
 }
 
 // NewMissingError is the building block for errors usually thrown when required
@@ -423,12 +450,10 @@ func (cE *CustomError) NewMissingError(opts ...Option) error {
 	finalCE := cE.X(Missing.String(), opts...)
 
 	if finalCE.language == "" {
-		finalCE = Copy(NewMissingError(finalCE.Message, opts...).(*CustomError), finalCE)
-
-		return finalCE
+		finalCE = Copy(newMissingError(finalCE.Message, finalCE.templateOptions(opts)...), finalCE)
 	}
 
-	return finalCE
+	return emit(finalCE)
 }
 
 // NewRequiredError is the building block for errors usually thrown when
@@ -442,12 +467,10 @@ func (cE *CustomError) NewRequiredError(opts ...Option) error {
 	finalCE := cE.X(Required.String(), opts...)
 
 	if finalCE.language == "" {
-		finalCE = Copy(NewRequiredError(finalCE.Message, opts...).(*CustomError), finalCE)
-
-		return finalCE
+		finalCE = Copy(newRequiredError(finalCE.Message, finalCE.templateOptions(opts)...), finalCE)
 	}
 
-	return finalCE
+	return emit(finalCE)
 }
 
 // NewHTTPError is the building block for simple HTTP errors, e.g.: Not Found.
@@ -469,7 +492,7 @@ func (cE *CustomError) NewHTTPError(statusCode int, opts ...Option) error {
 
 	finalCE = Copy(cE, finalCE)
 
-	httpCE := NewHTTPError(finalCE.StatusCode, opts...).(*CustomError)
+	httpCE := newHTTPError(finalCE.StatusCode, opts...)
 
 	finalErrorMessage := httpCE.Message
 
@@ -482,7 +505,7 @@ func (cE *CustomError) NewHTTPError(statusCode int, opts ...Option) error {
 
 	finalCE = Copy(httpCE, finalCE)
 
-	return finalCE
+	return emit(finalCE)
 }
 
 // New is the building block for other errors. Preferred method to be used for
@@ -501,9 +524,9 @@ func (cE *CustomError) New(opts ...Option) error {
 		opt(finalCE)
 	}
 
-	finalCE = Copy(New(finalCE.Message, opts...).(*CustomError), finalCE)
+	finalCE = Copy(build(finalCE.Message, opts...), finalCE)
 
-	return finalCE
+	return emit(finalCE)
 }
 
 //////
@@ -564,6 +587,11 @@ func new(opts ...Option) *CustomError {
 		cE.Message = cE.Code
 	}
 
+	if cE.Severity == 0 {
+		cE.Severity = cE.defaultSeverity()
+		cE.severityDefault = true
+	}
+
 	// Should be able to programatically ignore errors (`WithIgnoreFunc`).
 	if cE.ignore {
 		return nil
@@ -572,15 +600,15 @@ func new(opts ...Option) *CustomError {
 	return cE
 }
 
-// New creates a new validated custom error returning it as en `error`.
-func New(message string, opts ...Option) error {
+// build creates a new validated custom error. It doesn't emit it, see `emit`.
+func build(message string, opts ...Option) *CustomError {
 	cE := new(prependOptions(opts, WithMessage(message))...)
 
 	if cE == nil {
 		return nil
 	}
 
-	if err := validator.New().Struct(cE); err != nil {
+	if err := getValidator().Struct(cE); err != nil {
 		if os.Getenv("CUSTOMERROR_ENVIRONMENT") == "testing" {
 			log.Panicf("Invalid custom error. %s\n", err)
 		} else {
@@ -593,6 +621,11 @@ func New(message string, opts ...Option) error {
 	return cE
 }
 
+// New creates a new validated custom error returning it as en `error`.
+func New(message string, opts ...Option) error {
+	return emit(build(message, opts...))
+}
+
 // Factory creates a validated and pre-defined error to be recalled and thrown
 // later, with or without options. Possible options are:
 // - `NewFailedToError`
@@ -10,10 +10,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
-	"sync"
 	"testing"
 
-	"github.com/emirpasic/gods/sets/treeset"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -353,10 +351,6 @@ func TestNew_InvalidCustomError(t *testing.T) {
 }
 
 func Test_CustomError_MarshalJSON(t *testing.T) {
-	fields := sync.Map{}
-	fields.Store("field1", "value1")
-	fields.Store("field2", 2)
-
 	tests := []struct {
 		name     string
 		cE       *CustomError
@@ -367,13 +361,26 @@ func Test_CustomError_MarshalJSON(t *testing.T) {
 			cE: &CustomError{
 				Code:       "E1010",
 				Err:        errors.New("Some error"),
-				Fields:     &fields,
+				Fields:     NewFields(map[string]any{"field1": "value1", "field2": 2}),
 				Message:    "An error occurred",
 				StatusCode: http.StatusBadRequest,
-				Tags:       &Set{treeset.NewWithStringComparator("tag1", "tag2")},
+				Tags:       NewSet("tag2", "tag1"),
 				ignore:     false,
 			},
-			expected: `{"code":"E1010","field1":"value1","field2":2,"message":"An error occurred. Original Error: Some error","tags":["tag1","tag2"]}`,
+			expected: `{"code":"E1010","field1":"value1","field2":2,"message":"An error occurred","tags":["tag1","tag2"]}`,
+		},
+		{
+			name: "with all fields - internal",
+			cE: &CustomError{
+				Code:       "E1010",
+				Err:        errors.New("Some error"),
+				Fields:     NewFields(map[string]any{"field1": "value1", "field2": 2}),
+				Message:    "An error occurred",
+				StatusCode: http.StatusBadRequest,
+				Tags:       NewSet("tag2", "tag1"),
+				renderMode: RenderInternal,
+			},
+			expected: `{"cause":"Some error","code":"E1010","field1":"value1","field2":2,"message":"An error occurred","tags":["tag1","tag2"]}`,
 		},
 		{
 			name: "with message only",
@@ -392,3 +399,179 @@ func Test_CustomError_MarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomError_copyOnWrite(t *testing.T) {
+	factory := Factory(
+		"id",
+		WithField("key1", "value1"),
+		WithTag("tag1"),
+		WithTranslation("pt-BR", "id"),
+	)
+
+	cE := factory.NewChildError(
+		WithField("key1", "overridden"),
+		WithField("key2", "value2"),
+		WithTag("tag2"),
+		WithTranslation("es-ES", "id"),
+	)
+
+	// Child must see both, with its own values winning.
+	value1, _ := cE.Fields.Load("key1")
+	assert.Equal(t, "overridden", value1)
+	assert.Equal(t, []string{"key1", "key2"}, cE.Fields.Keys())
+	assert.Equal(t, []string{"tag1", "tag2"}, cE.Tags.Values())
+	assert.Len(t, cE.LanguageMessageMap, 2)
+
+	// Factory must be untouched.
+	value1, _ = factory.Fields.Load("key1")
+	assert.Equal(t, "value1", value1)
+	assert.Equal(t, 1, factory.Fields.Len())
+	assert.Equal(t, []string{"tag1"}, factory.Tags.Values())
+	assert.Len(t, factory.LanguageMessageMap, 1)
+
+	assert.Equal(t, "id. Tags: tag1, tag2. Fields: key1=overridden, key2=value2", cE.Error())
+}
+
+// allocationBudget is the maximum number of allocations per operation of the
+// hot paths. It was recorded from the benchmarks, plus some slack. If a change
+// makes a hot path allocate more, either fix it, or - if justified - update
+// the budget.
+var allocationBudget = map[string]struct {
+	budget float64
+	fn     func()
+}{
+	"New": {
+		budget: 14,
+		fn: func() {
+			_ = New(failedCreateSomethingMsg, WithErrorCode(code), WithField("key", "value"), WithTag("tag1", "tag2"))
+		},
+	},
+	"Factory.NewInvalidError": {
+		budget: 17,
+		fn: func() {
+			_ = benchFactory.NewInvalidError(WithField("key2", "value2"))
+		},
+	},
+	"Factory.NewInvalidError.WithLanguage": {
+		budget: 8,
+		fn: func() {
+			_ = benchFactory.NewInvalidError(WithLanguage("pt-BR"))
+		},
+	},
+	"Catalog.Get": {
+		budget: 1,
+		fn: func() {
+			_, _ = benchCatalog.Get("E1010")
+		},
+	},
+	"Error": {
+		budget: 14,
+		fn: func() {
+			_ = benchCustomError.Error()
+		},
+	},
+	"APIError": {
+		budget: 18,
+		fn: func() {
+			_ = benchCustomError.APIError()
+		},
+	},
+	"MarshalJSON": {
+		budget: 28,
+		fn: func() {
+			_, _ = json.Marshal(benchCustomError)
+		},
+	},
+}
+
+var (
+	benchCatalog     = MustNewCatalog("myapp").MustSet("E1010", "invalid response")
+	benchCustomError = Factory(failedCreateSomethingMsg, WithErrorCode(code), WithStatusCode(statusCode), WithField("key1", 1), WithField("key2", "value2"), WithTag("tag1", "tag2"))
+	benchFactory     = Factory("id", WithField("key", "value"), WithTag("tag1"), WithTranslation("pt-BR", "id"))
+)
+
+func TestAllocationBudget(t *testing.T) {
+	if raceEnabled {
+		t.Skip("race detector changes allocation counts")
+	}
+
+	// Warm-up singletons (validator, language maps, etc).
+	for _, tt := range allocationBudget {
+		tt.fn()
+	}
+
+	for name, tt := range allocationBudget {
+		t.Run(name, func(t *testing.T) {
+			if got := testing.AllocsPerRun(100, tt.fn); got > tt.budget {
+				t.Errorf("%s allocates %.0f per op, budget is %.0f", name, got, tt.budget)
+			}
+		})
+	}
+}
+
+//////
+// Benchmarks.
+//////
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = New(failedCreateSomethingMsg, WithErrorCode(code), WithField("key", "value"), WithTag("tag1", "tag2"))
+	}
+}
+
+func BenchmarkFactory_NewInvalidError(b *testing.B) {
+	factory := Factory("id", WithField("key", "value"), WithTag("tag1"), WithTranslation("pt-BR", "id"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = factory.NewInvalidError(WithField("key2", "value2"))
+	}
+}
+
+func BenchmarkCustomError_Error(b *testing.B) {
+	cE := Factory(failedCreateSomethingMsg, WithErrorCode(code), WithField("key1", 1), WithField("key2", "value2"), WithTag("tag1", "tag2"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = cE.Error()
+	}
+}
+
+func BenchmarkFactory_NewInvalidError_withLanguage(b *testing.B) {
+	factory := Factory("insert id", WithTranslation("pt-BR", "id"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = factory.NewInvalidError(WithLanguage("pt-BR"))
+	}
+}
+
+func BenchmarkCustomError_APIError(b *testing.B) {
+	cE := Factory(failedCreateSomethingMsg, WithErrorCode(code), WithStatusCode(statusCode), WithField("key1", 1), WithField("key2", "value2"), WithTag("tag1", "tag2"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = cE.APIError()
+	}
+}
+
+func BenchmarkCustomError_MarshalJSON(b *testing.B) {
+	cE := Factory(failedCreateSomethingMsg, WithErrorCode(code), WithField("key1", 1), WithField("key2", "value2"), WithTag("tag1", "tag2"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = json.Marshal(cE)
+	}
+}
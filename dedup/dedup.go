@@ -0,0 +1,267 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package dedup
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/thalesfsp/customerror"
+	"github.com/thalesfsp/customerror/reporter"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+const (
+	// DefaultWindow is the default deduplication window.
+	DefaultWindow = time.Minute
+
+	// FieldDuplicates is the field, or attribute, of summaries with the
+	// number of suppressed occurrences.
+	FieldDuplicates = "duplicates"
+)
+
+// ErrInvalidWindow is returned when the window isn't positive.
+var ErrInvalidWindow = errors.New("dedup: invalid window, it must be positive")
+
+type (
+	// KeyFunc returns the key used to group errors.
+	KeyFunc func(cE *customerror.CustomError) string
+
+	// Option allows to define deduplicator options.
+	Option func(d *Deduplicator)
+
+	// Deduplicator groups errors by key, within a window. It's safe for
+	// concurrent use.
+	Deduplicator struct {
+		done    chan struct{}
+		groups  map[string]*group
+		keyFunc KeyFunc
+		mu      sync.Mutex
+		now     func() time.Time
+		once    sync.Once
+		window  time.Duration
+	}
+
+	// group is a set of occurrences of the same error, within a window.
+	group struct {
+		// start of the window.
+		start time.Time
+
+		// summarize emits the summary of the group.
+		summarize func(suppressed uint64)
+
+		// suppressed occurrences.
+		suppressed uint64
+	}
+
+	// middleware is the `reporter.Reporter` middleware.
+	middleware struct {
+		d    *Deduplicator
+		next reporter.Reporter
+	}
+)
+
+//////
+// Helpers.
+//////
+
+// observe records an occurrence of `key`. It returns true if it's the first
+// one within the window - which starts at it -, thus it should be emitted.
+// `summarize` is called when the window ends, if there were suppressed
+// occurrences.
+func (d *Deduplicator) observe(key string, summarize func(suppressed uint64)) bool {
+	now := d.now()
+
+	d.mu.Lock()
+
+	g, ok := d.groups[key]
+	if ok && now.Sub(g.start) < d.window {
+		g.suppressed++
+
+		d.mu.Unlock()
+
+		return false
+	}
+
+	d.groups[key] = &group{start: now, summarize: summarize}
+
+	d.mu.Unlock()
+
+	// Ended, but not expired yet.
+	if ok && g.suppressed > 0 {
+		g.summarize(g.suppressed)
+	}
+
+	return true
+}
+
+// expire ends the groups whose window ended, emitting their summaries. If
+// `all` is true, every group is ended. It returns when the next window ends,
+// or the window, if there are no groups.
+func (d *Deduplicator) expire(all bool) time.Duration {
+	var ended []*group
+
+	now := d.now()
+	next := d.window
+
+	d.mu.Lock()
+
+	for key, g := range d.groups {
+		left := d.window - now.Sub(g.start)
+
+		if all || left <= 0 {
+			ended = append(ended, g)
+
+			delete(d.groups, key)
+
+			continue
+		}
+
+		if left < next {
+			next = left
+		}
+	}
+
+	d.mu.Unlock()
+
+	// Summaries are emitted without holding the lock, they may report
+	// errors.
+	for _, g := range ended {
+		if g.suppressed > 0 {
+			g.summarize(g.suppressed)
+		}
+	}
+
+	return next
+}
+
+// run expires groups when their window ends, until closed. Groups started
+// while waiting end later than the awaited one, so they're never late.
+func (d *Deduplicator) run() {
+	timer := time.NewTimer(d.window)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			timer.Reset(d.expire(false))
+		case <-d.done:
+			return
+		}
+	}
+}
+
+//////
+// Options.
+//////
+
+// WithKeyFunc allows to specify how errors are grouped. Default is
+// `DefaultKeyFunc`.
+func WithKeyFunc(keyFunc KeyFunc) Option {
+	return func(d *Deduplicator) {
+		d.keyFunc = keyFunc
+	}
+}
+
+// WithWindow allows to specify the deduplication window. It must be positive.
+// Default is `DefaultWindow`.
+func WithWindow(window time.Duration) Option {
+	return func(d *Deduplicator) {
+		d.window = window
+	}
+}
+
+//////
+// Methods.
+//////
+
+// Report implements the `reporter.Reporter` interface.
+func (m *middleware) Report(cE *customerror.CustomError) {
+	if cE == nil {
+		return
+	}
+
+	if m.d.observe(m.d.keyFunc(cE), func(suppressed uint64) {
+		m.next.Report(cE.NewChildError(customerror.WithField(FieldDuplicates, suppressed)))
+	}) {
+		m.next.Report(cE)
+	}
+}
+
+// Reporter returns a `reporter.Reporter` middleware which deduplicates errors
+// before reporting them to `next`. Summaries are copies of the first
+// occurrence, with the `FieldDuplicates` field.
+func (d *Deduplicator) Reporter(next reporter.Reporter) reporter.Reporter {
+	return &middleware{d: d, next: next}
+}
+
+// Flush ends all windows, emitting their summaries.
+func (d *Deduplicator) Flush() {
+	d.expire(true)
+}
+
+// Close stops the deduplicator, and flushes it. It's safe to call it multiple
+// times.
+func (d *Deduplicator) Close() {
+	d.once.Do(func() {
+		close(d.done)
+	})
+
+	d.Flush()
+}
+
+//////
+// Exported functionalities.
+//////
+
+// DefaultKeyFunc groups errors by fingerprint, see
+// `customerror.CustomError.Fingerprint`.
+func DefaultKeyFunc(cE *customerror.CustomError) string {
+	return cE.Fingerprint()
+}
+
+//////
+// Factory.
+//////
+
+// New creates a new deduplicator, and starts it. Call `Close` to emit pending
+// summaries on shutdown.
+func New(opts ...Option) (*Deduplicator, error) {
+	d := &Deduplicator{
+		done:    make(chan struct{}),
+		groups:  map[string]*group{},
+		keyFunc: DefaultKeyFunc,
+		now:     time.Now,
+		window:  DefaultWindow,
+	}
+
+	// Apply options.
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	if d.window <= 0 {
+		return nil, fmt.Errorf("%w. Window: %s", ErrInvalidWindow, d.window)
+	}
+
+	go d.run()
+
+	return d, nil
+}
+
+// MustNew is like `New`, but panics on error.
+func MustNew(opts ...Option) *Deduplicator {
+	d, err := New(opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
@@ -0,0 +1,164 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package dedup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalesfsp/customerror"
+	"github.com/thalesfsp/customerror/reporter"
+)
+
+// newTestDeduplicator returns a deduplicator with a controllable clock, and
+// a window long enough to never end by itself.
+func newTestDeduplicator(opts ...Option) (*Deduplicator, *time.Time) {
+	now := time.Date(2023, 3, 29, 10, 0, 0, 0, time.UTC)
+
+	d := MustNew(append([]Option{WithWindow(time.Hour)}, opts...)...)
+
+	d.now = func() time.Time { return now }
+
+	return d, &now
+}
+
+func TestDeduplicator_Reporter(t *testing.T) {
+	var reported []string
+
+	d, now := newTestDeduplicator()
+	defer d.Close()
+
+	r := d.Reporter(reporter.Func(func(cE *customerror.CustomError) {
+		reported = append(reported, cE.Error())
+	}))
+
+	userNotFound := customerror.Factory("user not found", customerror.WithErrorCode("E1010"))
+
+	for i := 0; i < 2; i++ {
+		r.Report(userNotFound)
+	}
+
+	// Same fingerprint, field values don't matter.
+	r.Report(userNotFound.NewChildError(customerror.WithField("id", 2)))
+
+	// Unless opted in.
+	r.Report(userNotFound.NewChildError(customerror.WithField("id", 1), customerror.WithFingerprintFields("id")))
+	r.Report(customerror.Factory("some error"))
+
+	// Not expired yet.
+	*now = now.Add(30 * time.Minute)
+
+	d.expire(false)
+
+	r.Report(userNotFound)
+
+	assert.Equal(t, []string{
+		"E1010: user not found",
+		"E1010: user not found. Fields: id=1",
+		"some error",
+	}, reported)
+
+	*now = now.Add(30 * time.Minute)
+
+	d.expire(false)
+
+	// New window.
+	r.Report(userNotFound)
+
+	assert.Equal(t, []string{
+		"E1010: user not found",
+		"E1010: user not found. Fields: id=1",
+		"some error",
+		"E1010: user not found. Fields: duplicates=3",
+		"E1010: user not found",
+	}, reported)
+}
+
+func TestDeduplicator_Close(t *testing.T) {
+	var reported []string
+
+	d, _ := newTestDeduplicator(WithKeyFunc(func(cE *customerror.CustomError) string {
+		return cE.Code
+	}))
+
+	r := d.Reporter(reporter.Func(func(cE *customerror.CustomError) {
+		reported = append(reported, cE.Error())
+	}))
+
+	for i := 0; i < 3; i++ {
+		r.Report(customerror.Factory(fmt.Sprintf("user %d not found", i), customerror.WithErrorCode("E1010")))
+	}
+
+	d.Close()
+	d.Close()
+
+	assert.Equal(t, []string{
+		"E1010: user 0 not found",
+		"E1010: user 0 not found. Fields: duplicates=2",
+	}, reported)
+}
+
+func TestDeduplicator_window(t *testing.T) {
+	var reported []string
+
+	d, now := newTestDeduplicator(WithKeyFunc(func(cE *customerror.CustomError) string {
+		return cE.Code
+	}))
+	defer d.Close()
+
+	r := d.Reporter(reporter.Func(func(cE *customerror.CustomError) {
+		reported = append(reported, cE.Error())
+	}))
+
+	report := func(codes ...string) {
+		for _, code := range codes {
+			r.Report(customerror.Factory("some error", customerror.WithErrorCode(code)))
+		}
+	}
+
+	report("E1", "E1")
+
+	*now = now.Add(30 * time.Minute)
+
+	report("E2", "E2")
+
+	// Windows are per key: E1 ends, E2 ends in 30 minutes.
+	*now = now.Add(30 * time.Minute)
+
+	assert.Equal(t, 30*time.Minute, d.expire(false))
+
+	assert.Equal(t, []string{
+		"E1: some error",
+		"E2: some error",
+		"E1: some error. Fields: duplicates=1",
+	}, reported)
+
+	// E2 ended, even if not expired yet.
+	*now = now.Add(30 * time.Minute)
+
+	report("E2")
+
+	assert.Equal(t, []string{
+		"E1: some error",
+		"E2: some error",
+		"E1: some error. Fields: duplicates=1",
+		"E2: some error. Fields: duplicates=1",
+		"E2: some error",
+	}, reported)
+
+	// Nothing to expire, the next window can't end before a window.
+	assert.Equal(t, time.Hour, d.expire(false))
+}
+
+func TestNew(t *testing.T) {
+	for _, window := range []time.Duration{0, -time.Second} {
+		d, err := New(WithWindow(window))
+
+		assert.ErrorIs(t, err, ErrInvalidWindow)
+		assert.Nil(t, d)
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package dedup deduplicates custom errors, so an outage doesn't log the same
+// error thousands of times per second.
+//
+// Errors are grouped by key - by default, their fingerprint, see
+// `customerror.CustomError.Fingerprint`, so the same error in different
+// languages, or with different IDs, is grouped together. Within
+// a window, the first occurrence of a group is emitted immediately, the
+// following ones are suppressed, and counted. When the window ends, a summary
+// with the number of suppressed occurrences is emitted.
+//
+// It's exposed as a `reporter.Reporter` middleware, and as a `slog.Handler`
+// (Go 1.21+).
+//
+// Example:
+//
+//	d := dedup.MustNew(dedup.WithWindow(time.Minute))
+//	defer d.Close()
+//
+//	logger := slog.New(d.Handler(slog.NewJSONHandler(os.Stderr, nil)))
+package dedup
@@ -0,0 +1,97 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+
+package dedup
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/thalesfsp/customerror"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// handler is the `slog.Handler`.
+type handler struct {
+	d    *Deduplicator
+	next slog.Handler
+}
+
+//////
+// Helpers.
+//////
+
+// customErrorOf returns the first custom error in the attributes of `r`, if
+// any.
+func customErrorOf(r slog.Record) *customerror.CustomError {
+	var cE *customerror.CustomError
+
+	r.Attrs(func(attr slog.Attr) bool {
+		err, ok := attr.Value.Resolve().Any().(error)
+		if !ok {
+			return true
+		}
+
+		return !errors.As(err, &cE)
+	})
+
+	return cE
+}
+
+//////
+// Methods.
+//////
+
+// Enabled implements the `slog.Handler` interface.
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements the `slog.Handler` interface.
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	cE := customErrorOf(r)
+	if cE == nil {
+		return h.next.Handle(ctx, r)
+	}
+
+	// The record may be reused after `Handle` returns.
+	first := r.Clone()
+
+	if h.d.observe(h.d.keyFunc(cE), func(suppressed uint64) {
+		summary := first.Clone()
+
+		summary.AddAttrs(slog.Uint64(FieldDuplicates, suppressed))
+
+		_ = h.next.Handle(context.Background(), summary)
+	}) {
+		return h.next.Handle(ctx, r)
+	}
+
+	return nil
+}
+
+// WithAttrs implements the `slog.Handler` interface.
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &handler{d: h.d, next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup implements the `slog.Handler` interface.
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{d: h.d, next: h.next.WithGroup(name)}
+}
+
+// Handler returns a `slog.Handler` which deduplicates records with a custom
+// error attribute, e.g.: `logger.Error("request failed", "error", err)`,
+// before handling them with `next`. Records without one are handled as they
+// are. Summaries are copies of the first occurrence, with the
+// `FieldDuplicates` attribute, logged when the window ends.
+func (d *Deduplicator) Handler(next slog.Handler) slog.Handler {
+	return &handler{d: d, next: next}
+}
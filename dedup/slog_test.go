@@ -0,0 +1,51 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+
+package dedup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalesfsp/customerror"
+)
+
+func TestDeduplicator_Handler(t *testing.T) {
+	var buf bytes.Buffer
+
+	d, _ := newTestDeduplicator()
+
+	logger := slog.New(d.Handler(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))).With("service", "api")
+
+	err := customerror.NewNotFoundError("user", customerror.WithErrorCode("E1010"))
+
+	for i := 0; i < 3; i++ {
+		logger.Error("request failed", "error", fmt.Errorf("handler: %w", err), "attempt", i)
+	}
+
+	logger.Error("request failed", "error", errors.New("not a custom error"))
+	logger.Error("request failed", "error", errors.New("not a custom error"))
+
+	d.Close()
+
+	assert.Equal(t, `level=ERROR msg="request failed" service=api error="handler: E1010: user not found" attempt=0
+level=ERROR msg="request failed" service=api error="not a custom error"
+level=ERROR msg="request failed" service=api error="not a custom error"
+level=ERROR msg="request failed" service=api error="handler: E1010: user not found" attempt=0 duplicates=2
+`, buf.String())
+}
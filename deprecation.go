@@ -0,0 +1,219 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// ErrCatalogAliasConflict is returned when an alias is already a code, or an
+// alias of another code of the catalog.
+var ErrCatalogAliasConflict = NewInvalidError("alias. It's already a code, or an alias of another code", WithErrorCode("CE_ERR_CATALOG_ALIAS_CONFLICT"))
+
+// Deprecation is the deprecation metadata of an error, see `WithDeprecation`,
+// and `Catalog.Alias`.
+type Deprecation struct {
+	// Code is the deprecated code, set by the catalog.
+	Code string
+
+	// Replacement is the code which replaces it, if any.
+	Replacement string
+
+	// Sunset is when it's removed, if known.
+	Sunset time.Time
+}
+
+//////
+// Helpers.
+//////
+
+// lookup returns the entry for `errorCode`, resolved - it may be an alias -,
+// and its code.
+func (c *Catalog) lookup(errorCode ErrorCode) (*CustomError, ErrorCode, bool) {
+	if customErr, ok := c.ErrorCodeErrorMap.Load(errorCode); ok {
+		return customErr.(*CustomError), errorCode, true
+	}
+
+	code, ok := c.aliases.Load(errorCode)
+	if !ok {
+		return nil, "", false
+	}
+
+	customErr, ok := c.ErrorCodeErrorMap.Load(code)
+	if !ok {
+		// Deleted meanwhile.
+		return nil, "", false
+	}
+
+	return customErr.(*CustomError), code.(ErrorCode), true
+}
+
+// isLocal returns true if `errorCode` is set, and not qualified with the name
+// of another catalog.
+func (c *Catalog) isLocal(errorCode string) bool {
+	name, _, qualified := strings.Cut(errorCode, NamespaceSeparator)
+
+	return errorCode != "" && (!qualified || name == c.Name)
+}
+
+// exists returns an error if there's no error, nor alias, with `errorCode`.
+func (c *Catalog) exists(errorCode string) error {
+	eC, err := c.resolve(errorCode)
+	if err != nil {
+		return err
+	}
+
+	if _, _, ok := c.lookup(eC); !ok {
+		return fmt.Errorf("%w. Code: %s", ErrCatalogErrorNotFound, eC)
+	}
+
+	return nil
+}
+
+// deprecate sets the deprecation metadata of `cE`, got with `requested` from
+// the entry with `code`. If requested by an alias, it's deprecated in favor
+// of `code`.
+func deprecate(cE *CustomError, requested, code ErrorCode) {
+	if requested != code {
+		cE.deprecation = &Deprecation{Code: requested.String(), Replacement: code.String()}
+
+		return
+	}
+
+	if cE.deprecation != nil {
+		d := *cE.deprecation
+
+		d.Code = code.String()
+
+		cE.deprecation = &d
+	}
+}
+
+//////
+// Methods.
+//////
+
+// String implements the Stringer interface.
+func (d Deprecation) String() string {
+	msg := fmt.Sprintf("code %s is deprecated", d.Code)
+
+	if d.Replacement != "" {
+		msg += fmt.Sprintf(", use %s instead", d.Replacement)
+	}
+
+	if !d.Sunset.IsZero() {
+		msg += fmt.Sprintf(". Sunset: %s", d.Sunset.Format(time.RFC3339))
+	}
+
+	return msg
+}
+
+// Deprecation returns the deprecation metadata of the error, if deprecated.
+// If not set, its code is the error code.
+func (cE *CustomError) Deprecation() (Deprecation, bool) {
+	if cE.deprecation == nil {
+		return Deprecation{}, false
+	}
+
+	d := *cE.deprecation
+
+	if d.Code == "" {
+		d.Code = cE.Code
+	}
+
+	return d, true
+}
+
+// Alias makes `aliases` resolve to the error with `errorCode`, e.g.: old codes
+// of a renamed error. Errors got by an alias are deprecated in favor of
+// `errorCode`, see `OnDeprecated`.
+func (c *Catalog) Alias(errorCode string, aliases ...string) error {
+	eC, err := c.resolve(errorCode)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := c.ErrorCodeErrorMap.Load(eC); !ok {
+		return fmt.Errorf("%w. Code: %s", ErrCatalogErrorNotFound, eC)
+	}
+
+	resolved := make([]ErrorCode, 0, len(aliases))
+
+	for _, alias := range aliases {
+		aC, err := c.resolve(alias)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := c.ErrorCodeErrorMap.Load(aC); ok {
+			return fmt.Errorf("%w. Alias: %s", ErrCatalogAliasConflict, aC)
+		}
+
+		if code, ok := c.aliases.Load(aC); ok && code.(ErrorCode) != eC {
+			return fmt.Errorf("%w. Alias: %s. Code: %s", ErrCatalogAliasConflict, aC, code)
+		}
+
+		resolved = append(resolved, aC)
+	}
+
+	for _, aC := range resolved {
+		c.aliases.Store(aC, eC)
+	}
+
+	return nil
+}
+
+// MustAlias is like `Alias`, but panics on error.
+func (c *Catalog) MustAlias(errorCode string, aliases ...string) *Catalog {
+	if err := c.Alias(errorCode, aliases...); err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// Aliases returns the aliases of the error with `errorCode`, sorted.
+func (c *Catalog) Aliases(errorCode string) []string {
+	eC, err := c.resolve(errorCode)
+	if err != nil {
+		return nil
+	}
+
+	var aliases []string
+
+	c.aliases.Range(func(alias, code any) bool {
+		if code.(ErrorCode) == eC {
+			aliases = append(aliases, alias.(ErrorCode).String())
+		}
+
+		return true
+	})
+
+	sort.Strings(aliases)
+
+	return aliases
+}
+
+//////
+// Exported functionalities.
+//////
+
+// LogDeprecated is a hook which logs deprecated errors, e.g.:
+// `RegisterHook(OnDeprecated, LogDeprecated)`.
+func LogDeprecated(cE *CustomError) bool {
+	if d, ok := cE.Deprecation(); ok {
+		log.Println(d)
+	}
+
+	return false
+}
@@ -0,0 +1,154 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSunset = time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestCatalog_Alias(t *testing.T) {
+	catalog := MustNewCatalog("myapp", WithCodePrefix()).
+		MustSet("ERR_A1_B2", "user not found").
+		MustSet("ERR_A1_B3", "invalid user", WithDeprecation("ERR_A1_B2", testSunset)).
+		MustAlias("ERR_A1_B2", "ERR_A0_B2", "E1010")
+
+	tests := []struct {
+		name            string
+		code            string
+		wantCode        string
+		wantDeprecation *Deprecation
+	}{
+		{
+			name:     "Should get by code",
+			code:     "ERR_A1_B2",
+			wantCode: "MYAPP_ERR_A1_B2",
+		},
+		{
+			name:     "Should get by alias",
+			code:     "E1010",
+			wantCode: "MYAPP_ERR_A1_B2",
+			wantDeprecation: &Deprecation{
+				Code:        "MYAPP_E1010",
+				Replacement: "MYAPP_ERR_A1_B2",
+			},
+		},
+		{
+			name:     "Should get by alias - qualified",
+			code:     "myapp:ERR_A0_B2",
+			wantCode: "MYAPP_ERR_A1_B2",
+			wantDeprecation: &Deprecation{
+				Code:        "MYAPP_ERR_A0_B2",
+				Replacement: "MYAPP_ERR_A1_B2",
+			},
+		},
+		{
+			name:     "Should get deprecated",
+			code:     "ERR_A1_B3",
+			wantCode: "MYAPP_ERR_A1_B3",
+			wantDeprecation: &Deprecation{
+				Code:        "MYAPP_ERR_A1_B3",
+				Replacement: "ERR_A1_B2",
+				Sunset:      testSunset,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cE, err := catalog.Get(tt.code)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.wantCode, cE.Code)
+
+			d, ok := cE.Deprecation()
+
+			assert.Equal(t, tt.wantDeprecation != nil, ok)
+
+			if tt.wantDeprecation != nil {
+				assert.Equal(t, *tt.wantDeprecation, d)
+			}
+		})
+	}
+
+	assert.Equal(t, []string{"MYAPP_E1010", "MYAPP_ERR_A0_B2"}, catalog.Aliases("ERR_A1_B2"))
+	assert.NoError(t, catalog.Validate())
+
+	// Conflicts.
+	assert.ErrorIs(t, catalog.Alias("ERR_A1_B3", "ERR_A1_B2"), ErrCatalogAliasConflict)
+	assert.ErrorIs(t, catalog.Alias("ERR_A1_B3", "E1010"), ErrCatalogAliasConflict)
+	assert.ErrorIs(t, catalog.Alias("ERR_A9_B9", "E2020"), ErrCatalogErrorNotFound)
+
+	_, err := catalog.Set("E1010", "invalid response")
+	assert.ErrorIs(t, err, ErrCatalogAliasConflict)
+
+	// Set, including aliases.
+	set := MustNewCatalogSet(catalog)
+
+	cE, err := set.Get("MYAPP_E1010")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "MYAPP_ERR_A1_B2", cE.Code)
+	}
+
+	_, err = NewCatalogSet(catalog, MustNewCatalog("otherapp").MustSet("MYAPP_E1010", "invalid response"))
+	assert.ErrorIs(t, err, ErrCatalogCodeConflict)
+
+	// Deleting removes aliases.
+	assert.True(t, catalog.Delete("ERR_A1_B2"))
+	assert.Empty(t, catalog.Aliases("ERR_A1_B2"))
+
+	_, err = catalog.Get("E1010")
+	assert.ErrorIs(t, err, ErrCatalogErrorNotFound)
+
+	// Replacement is gone.
+	assert.ErrorIs(t, catalog.Validate(), ErrCatalogInvalid)
+}
+
+func TestOnDeprecated(t *testing.T) {
+	catalog := MustNewCatalog("myapp").
+		MustSet("E2020", "user not found").
+		MustAlias("E2020", "E1010")
+
+	var buf bytes.Buffer
+
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	flags := log.Flags()
+
+	log.SetFlags(0)
+	defer log.SetFlags(flags)
+
+	var deprecated []string
+
+	defer RegisterHook(OnDeprecated, func(cE *CustomError) bool {
+		d, _ := cE.Deprecation()
+
+		deprecated = append(deprecated, d.Code)
+
+		return false
+	})()
+
+	defer RegisterHook(OnDeprecated, LogDeprecated)()
+
+	_, _ = catalog.Emit("E2020")
+	_, _ = catalog.Emit("E1010")
+
+	// Emitted once, by the factory method.
+	_ = catalog.MustGet("E1010").NewInvalidError()
+
+	_ = New("some error", WithErrorCode("E3030"), WithDeprecation("", testSunset))
+
+	assert.Equal(t, []string{"E1010", "E1010", "E3030"}, deprecated)
+	assert.Equal(t, "code E1010 is deprecated, use E2020 instead\ncode E1010 is deprecated, use E2020 instead\ncode E3030 is deprecated. Sunset: 2027-01-01T00:00:00Z\n", buf.String())
+}
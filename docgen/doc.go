@@ -0,0 +1,17 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package docgen renders a `customerror.Catalog` into documentation for API
+// consumers: Markdown, HTML, and an OpenAPI components fragment. There's one
+// section per code with its message, status code, tags, default fields, every
+// translation, and example JSON bodies produced by the real `MarshalJSON`.
+//
+// Example:
+//
+//	if err := docgen.Markdown(os.Stdout, catalog); err != nil {
+//		return err
+//	}
+//
+// See also the `customerror-docgen` command, which renders catalog files.
+package docgen
@@ -0,0 +1,385 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package docgen
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/thalesfsp/customerror"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// Formats.
+const (
+	// FormatHTML is a standalone HTML page.
+	FormatHTML Format = "html"
+
+	// FormatMarkdown is a Markdown document.
+	FormatMarkdown Format = "markdown"
+
+	// FormatOpenAPI is an OpenAPI components fragment, JSON encoded.
+	FormatOpenAPI Format = "openapi"
+)
+
+// Schemas, in the OpenAPI components fragment.
+const (
+	// SchemaCustomError is the schema of the JSON rendering, see
+	// `customerror.JSONSchema`.
+	SchemaCustomError = "CustomError"
+
+	// SchemaCustomErrorProblem is the schema of the problem details rendering,
+	// see `customerror.ProblemJSONSchema`.
+	SchemaCustomErrorProblem = "CustomErrorProblem"
+)
+
+var (
+	// ErrUnknownFormat is returned when a format isn't supported.
+	ErrUnknownFormat = customerror.NewInvalidError("format. Supported: html, markdown, openapi", customerror.WithErrorCode("CE_ERR_DOCGEN_UNKNOWN_FORMAT"))
+
+	//go:embed template
+	templates embed.FS
+
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("html.tmpl").Funcs(htmltemplate.FuncMap{
+		// Anchors are lower cased codes.
+		"lower": strings.ToLower,
+	}).ParseFS(templates, "template/html.tmpl"))
+
+	markdownTemplate = texttemplate.Must(texttemplate.New("markdown.tmpl").Funcs(texttemplate.FuncMap{
+		// Escapes table cells.
+		"cell": func(s string) string {
+			return strings.ReplaceAll(s, "|", `\|`)
+		},
+
+		// Anchors are lower cased codes.
+		"lower": strings.ToLower,
+	}).ParseFS(templates, "template/markdown.tmpl"))
+)
+
+type (
+	// Format is a documentation format.
+	Format string
+
+	// document is the data rendered by templates.
+	document struct {
+		Errors []entry
+		Name   string
+	}
+
+	// entry is a documented error.
+	entry struct {
+		Aliases      []string
+		Anchor       string
+		Code         string
+		Deprecated   bool
+		ErrorType    string
+		Examples     []example
+		Fields       []field
+		Message      string
+		Replacement  string
+		Severity     string
+		Status       string
+		StatusCode   int
+		Sunset       string
+		Tags         []string
+		Translations []translation
+	}
+
+	// example is an example JSON body.
+	example struct {
+		// Language of the example, empty for the default message.
+		Language string
+
+		// JSON is the indented body.
+		JSON string
+
+		// Title describes the example.
+		Title string
+
+		// Value is the body.
+		Value json.RawMessage
+	}
+
+	// field is a default field, its value JSON encoded.
+	field struct {
+		Key   string
+		Value string
+	}
+
+	// translation is a translated message.
+	translation struct {
+		Language string
+		Message  string
+	}
+)
+
+//////
+// Helpers.
+//////
+
+// newExample creates an example from `cE`, as marshaled by `MarshalJSON`.
+func newExample(language string, cE *customerror.CustomError) (example, error) {
+	b, err := json.MarshalIndent(cE, "", "  ")
+	if err != nil {
+		return example{}, customerror.NewFailedToError(
+			"marshal example",
+			customerror.WithError(err),
+			customerror.WithErrorCode("CE_ERR_DOCGEN_FAILED_TO_MARSHAL_EXAMPLE"),
+		)
+	}
+
+	title := "Default"
+	if language != "" {
+		title = fmt.Sprintf("Translated (%s)", language)
+	}
+
+	return example{Language: language, JSON: string(b), Title: title, Value: b}, nil
+}
+
+// newEntry creates a documented error from a catalog entry, and its error.
+func newEntry(catalogEntry customerror.CatalogEntry, cE *customerror.CustomError) (entry, error) {
+	e := entry{
+		Aliases:    catalogEntry.Aliases,
+		Anchor:     strings.ToLower(catalogEntry.Code),
+		Code:       catalogEntry.Code,
+		ErrorType:  catalogEntry.ErrorType.String(),
+		Message:    catalogEntry.Message,
+		Severity:   catalogEntry.Severity.String(),
+		StatusCode: catalogEntry.StatusCode,
+		Tags:       catalogEntry.Tags,
+	}
+
+	if e.StatusCode != 0 {
+		e.Status = fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+
+	if d := catalogEntry.Deprecation; d != nil {
+		e.Deprecated = true
+		e.Replacement = d.Replacement
+
+		if d.Sunset != nil {
+			e.Sunset = d.Sunset.Format("2006-01-02")
+		}
+	}
+
+	keys := make([]string, 0, len(catalogEntry.Fields))
+
+	for key := range catalogEntry.Fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		b, err := json.Marshal(catalogEntry.Fields[key])
+		if err != nil {
+			return entry{}, customerror.NewFailedToError(
+				"marshal field",
+				customerror.WithError(err),
+				customerror.WithErrorCode("CE_ERR_DOCGEN_FAILED_TO_MARSHAL_FIELD"),
+				customerror.WithField("key", key),
+			)
+		}
+
+		e.Fields = append(e.Fields, field{Key: key, Value: string(b)})
+	}
+
+	languages := make([]string, 0, len(catalogEntry.Translations))
+
+	for lang := range catalogEntry.Translations {
+		languages = append(languages, lang.String())
+	}
+
+	sort.Strings(languages)
+
+	defaultExample, err := newExample("", cE)
+	if err != nil {
+		return entry{}, err
+	}
+
+	e.Examples = append(e.Examples, defaultExample)
+
+	for _, lang := range languages {
+		e.Translations = append(e.Translations, translation{
+			Language: lang,
+			Message:  catalogEntry.Translations[customerror.Language(lang)],
+		})
+
+		translated := customerror.Copy(cE, &customerror.CustomError{})
+
+		customerror.WithLanguage(lang)(translated)
+
+		translatedExample, err := newExample(lang, translated)
+		if err != nil {
+			return entry{}, err
+		}
+
+		e.Examples = append(e.Examples, translatedExample)
+	}
+
+	return e, nil
+}
+
+// newDocument creates the document of `c`. Errors are sorted by code.
+func newDocument(c *customerror.Catalog) (document, error) {
+	doc := document{Name: c.Name}
+
+	var err error
+
+	// Copies, which aren't emitted - no hooks, or observers.
+	c.RangeEntries(func(catalogEntry customerror.CatalogEntry, cE *customerror.CustomError) bool {
+		// Examples show the code, as clients see it.
+		if cE.Code == "" {
+			customerror.WithErrorCode(catalogEntry.Code)(cE)
+		}
+
+		var e entry
+
+		e, err = newEntry(catalogEntry, cE)
+		if err != nil {
+			return false
+		}
+
+		doc.Errors = append(doc.Errors, e)
+
+		return true
+	})
+
+	if err != nil {
+		return document{}, err
+	}
+
+	return doc, nil
+}
+
+// openAPI returns the OpenAPI components fragment of `doc`: one response per
+// code, its examples, and the schemas of the error renderings.
+func openAPI(doc document) map[string]any {
+	examples := map[string]any{}
+	responses := map[string]any{}
+
+	for _, e := range doc.Errors {
+		refs := map[string]any{}
+
+		for _, ex := range e.Examples {
+			name := e.Code
+
+			summary := e.Message
+
+			if ex.Language != "" {
+				name += "_" + ex.Language
+
+				summary = fmt.Sprintf("%s (%s)", e.Message, ex.Language)
+			}
+
+			examples[name] = map[string]any{
+				"summary": summary,
+				"value":   ex.Value,
+			}
+
+			refs[name] = map[string]any{"$ref": "#/components/examples/" + name}
+		}
+
+		response := map[string]any{
+			"description": e.Message,
+			"content": map[string]any{
+				"application/json": map[string]any{
+					"examples": refs,
+					"schema":   map[string]any{"$ref": "#/components/schemas/" + SchemaCustomError},
+				},
+				"application/problem+json": map[string]any{
+					"schema": map[string]any{"$ref": "#/components/schemas/" + SchemaCustomErrorProblem},
+				},
+			},
+		}
+
+		if e.StatusCode != 0 {
+			response["x-status-code"] = e.StatusCode
+		}
+
+		responses[e.Code] = response
+	}
+
+	return map[string]any{
+		"components": map[string]any{
+			"examples":  examples,
+			"responses": responses,
+			"schemas": map[string]any{
+				SchemaCustomError:        customerror.JSONSchema(),
+				SchemaCustomErrorProblem: customerror.ProblemJSONSchema(),
+			},
+		},
+	}
+}
+
+//////
+// Exported functionalities.
+//////
+
+// HTML renders `c` as a standalone HTML page.
+func HTML(w io.Writer, c *customerror.Catalog) error {
+	return Render(w, c, FormatHTML)
+}
+
+// Markdown renders `c` as a Markdown document.
+func Markdown(w io.Writer, c *customerror.Catalog) error {
+	return Render(w, c, FormatMarkdown)
+}
+
+// OpenAPI renders `c` as an OpenAPI components fragment, JSON encoded: one
+// response per code (`#/components/responses/{code}`), with its examples
+// (`#/components/examples/{code}`, and `{code}_{language}`), and the schemas
+// of the JSON (`#/components/schemas/CustomError`), and problem details
+// (`#/components/schemas/CustomErrorProblem`) renderings.
+func OpenAPI(w io.Writer, c *customerror.Catalog) error {
+	return Render(w, c, FormatOpenAPI)
+}
+
+// Render renders `c` in `format`.
+func Render(w io.Writer, c *customerror.Catalog, format Format) error {
+	switch format {
+	case FormatHTML, FormatMarkdown, FormatOpenAPI:
+	default:
+		return fmt.Errorf("%w. Format: %s", ErrUnknownFormat, format)
+	}
+
+	doc, err := newDocument(c)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatHTML:
+		err = htmlTemplate.Execute(w, doc)
+	case FormatMarkdown:
+		err = markdownTemplate.Execute(w, doc)
+	case FormatOpenAPI:
+		encoder := json.NewEncoder(w)
+
+		encoder.SetIndent("", "  ")
+
+		err = encoder.Encode(openAPI(doc))
+	}
+
+	if err != nil {
+		return customerror.NewFailedToError(
+			"render documentation",
+			customerror.WithError(err),
+			customerror.WithErrorCode("CE_ERR_DOCGEN_FAILED_TO_RENDER"),
+		)
+	}
+
+	return nil
+}
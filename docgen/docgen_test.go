@@ -0,0 +1,87 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package docgen
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalesfsp/customerror"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func readTestCatalog(t *testing.T) *customerror.Catalog {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", "catalog.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	c, err := customerror.ReadCatalog(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		golden string
+	}{
+		{
+			name:   "Should render - html",
+			format: FormatHTML,
+			golden: "catalog.html",
+		},
+		{
+			name:   "Should render - markdown",
+			format: FormatMarkdown,
+			golden: "catalog.md",
+		},
+		{
+			name:   "Should render - openapi",
+			format: FormatOpenAPI,
+			golden: "catalog.openapi.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if !assert.NoError(t, Render(&buf, readTestCatalog(t), tt.format)) {
+				return
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+
+			if *update {
+				assert.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+			}
+
+			want, err := os.ReadFile(path)
+			assert.NoError(t, err)
+
+			assert.Equal(t, string(want), buf.String())
+		})
+	}
+}
+
+func TestRender_unknownFormat(t *testing.T) {
+	err := Render(&bytes.Buffer{}, readTestCatalog(t), "pdf")
+
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	assert.ErrorContains(t, err, "Format: pdf")
+}
@@ -0,0 +1,179 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+var (
+	// structuredErrorCodeRegex matches structured error codes, capturing
+	// their components, see `ErrorCodeComponents`.
+	structuredErrorCodeRegex = regexp.MustCompile(`^(?:(\d?[A-Z])_)?ERR_([A-Z]\d)_([A-Z]\d)(?:_(\d[A-Z]))?$`)
+
+	// Components.
+	domainRegex       = regexp.MustCompile(`^\d?[A-Z]$`)
+	letterNumberRegex = regexp.MustCompile(`^[A-Z]\d$`)
+	numberLetterRegex = regexp.MustCompile(`^\d[A-Z]$`)
+)
+
+// ErrorCodeComponents are the components of a structured error code, in the
+// "{domain}_ERR_{typeOf}_{subject}_{variant}" format, e.g.: 1A_ERR_A1_B2_3C.
+type ErrorCodeComponents struct {
+	// Domain is optional, an optional number followed by a letter, e.g.: 1A.
+	Domain string `json:"domain,omitempty"`
+
+	// TypeOf is required, a letter followed by a number, e.g.: A1.
+	TypeOf string `json:"typeOf"`
+
+	// Subject is required, a letter followed by a number, e.g.: B2.
+	Subject string `json:"subject"`
+
+	// Variant is optional, a number followed by a letter, e.g.: 3C.
+	Variant string `json:"variant,omitempty"`
+}
+
+//////
+// Helpers.
+//////
+
+// validateComponent validates a component against `re`. Optional components
+// may be empty.
+func validateComponent(name, value string, re *regexp.Regexp, optional bool) error {
+	if value == "" && optional {
+		return nil
+	}
+
+	if !re.MatchString(value) {
+		return fmt.Errorf("%w. Invalid %s: %q", ErrErrorCodeInvalidCode, name, value)
+	}
+
+	return nil
+}
+
+//////
+// Methods.
+//////
+
+// ErrorCode returns the error code, see `NewErrorCodeFrom`.
+func (c ErrorCodeComponents) ErrorCode() (ErrorCode, error) {
+	return NewErrorCodeFrom(c.Domain, c.TypeOf, c.Subject, c.Variant)
+}
+
+// Match returns true if `other` has the same non-empty components as `c`,
+// e.g.: {TypeOf: "A1"} matches every code of type "A1".
+func (c ErrorCodeComponents) Match(other ErrorCodeComponents) bool {
+	for _, pair := range [][2]string{
+		{c.Domain, other.Domain},
+		{c.TypeOf, other.TypeOf},
+		{c.Subject, other.Subject},
+		{c.Variant, other.Variant},
+	} {
+		if pair[0] != "" && !strings.EqualFold(pair[0], pair[1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetFrom returns a custom error from the catalog by the components of its
+// code, see `NewErrorCodeFrom`, and `Get`.
+func (c *Catalog) GetFrom(components ErrorCodeComponents, opts ...Option) (*CustomError, error) {
+	eC, err := components.ErrorCode()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Get(eC.String(), opts...)
+}
+
+//////
+// Exported functionalities.
+//////
+
+// ByComponents filters errors by the components of their codes, see
+// `ErrorCodeComponents.Match`. Catalog prefixes are ignored, and codes which
+// aren't structured don't match.
+func ByComponents(components ErrorCodeComponents) Filter {
+	return func(code string, cE *CustomError) bool {
+		if cE.catalog != nil && cE.catalog.prefix != "" {
+			code = strings.TrimPrefix(code, cE.catalog.prefix+"_")
+		}
+
+		parsed, err := ParseErrorCode(code)
+		if err != nil {
+			return false
+		}
+
+		return components.Match(parsed)
+	}
+}
+
+// ParseErrorCode returns the components of a structured error code, see
+// `ErrorCodeComponents`. Components are upper cased.
+func ParseErrorCode(code string) (ErrorCodeComponents, error) {
+	matches := structuredErrorCodeRegex.FindStringSubmatch(strings.ToUpper(code))
+	if matches == nil {
+		return ErrorCodeComponents{}, fmt.Errorf("%w. Code: %s", ErrErrorCodeInvalidCode, code)
+	}
+
+	return ErrorCodeComponents{
+		Domain:  matches[1],
+		TypeOf:  matches[2],
+		Subject: matches[3],
+		Variant: matches[4],
+	}, nil
+}
+
+//////
+// Factory.
+//////
+
+// NewErrorCodeFrom creates a structured error code from its components, e.g.:
+// ("1A", "A1", "B2", "3C") is "1A_ERR_A1_B2_3C". `domain`, and `variant` are
+// optional. Components are upper cased.
+func NewErrorCodeFrom(domain, typeOf, subject, variant string) (ErrorCode, error) {
+	domain = strings.ToUpper(domain)
+	typeOf = strings.ToUpper(typeOf)
+	subject = strings.ToUpper(subject)
+	variant = strings.ToUpper(variant)
+
+	if err := validateComponent("domain", domain, domainRegex, true); err != nil {
+		return "", err
+	}
+
+	if err := validateComponent("typeOf", typeOf, letterNumberRegex, false); err != nil {
+		return "", err
+	}
+
+	if err := validateComponent("subject", subject, letterNumberRegex, false); err != nil {
+		return "", err
+	}
+
+	if err := validateComponent("variant", variant, numberLetterRegex, true); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	if domain != "" {
+		sb.WriteString(domain + "_")
+	}
+
+	sb.WriteString("ERR_" + typeOf + "_" + subject)
+
+	if variant != "" {
+		sb.WriteString("_" + variant)
+	}
+
+	return NewErrorCode(sb.String())
+}
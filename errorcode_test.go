@@ -0,0 +1,151 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewErrorCodeFrom(t *testing.T) {
+	tests := []struct {
+		name       string
+		components ErrorCodeComponents
+		want       ErrorCode
+		wantErr    string
+	}{
+		{
+			name:       "Should work - all components",
+			components: ErrorCodeComponents{Domain: "1a", TypeOf: "a1", Subject: "b2", Variant: "3c"},
+			want:       "1A_ERR_A1_B2_3C",
+		},
+		{
+			name:       "Should work - required components",
+			components: ErrorCodeComponents{TypeOf: "A1", Subject: "B2"},
+			want:       "ERR_A1_B2",
+		},
+		{
+			name:       "Should work - domain without number",
+			components: ErrorCodeComponents{Domain: "A", TypeOf: "A1", Subject: "B2"},
+			want:       "A_ERR_A1_B2",
+		},
+		{
+			name:       "Should fail - missing typeOf",
+			components: ErrorCodeComponents{Subject: "B2"},
+			wantErr:    `Invalid typeOf: ""`,
+		},
+		{
+			name:       "Should fail - invalid subject",
+			components: ErrorCodeComponents{TypeOf: "A1", Subject: "2B"},
+			wantErr:    `Invalid subject: "2B"`,
+		},
+		{
+			name:       "Should fail - invalid domain",
+			components: ErrorCodeComponents{Domain: "12A", TypeOf: "A1", Subject: "B2"},
+			wantErr:    `Invalid domain: "12A"`,
+		},
+		{
+			name:       "Should fail - invalid variant",
+			components: ErrorCodeComponents{TypeOf: "A1", Subject: "B2", Variant: "C3"},
+			wantErr:    `Invalid variant: "C3"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.components.ErrorCode()
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrErrorCodeInvalidCode)
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// Round trip.
+			parsed, err := ParseErrorCode(got.String())
+
+			assert.NoError(t, err)
+			assert.True(t, tt.components.Match(parsed))
+		})
+	}
+}
+
+func TestParseErrorCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    ErrorCodeComponents
+		wantErr bool
+	}{
+		{
+			name: "Should work",
+			code: "1a_err_a1_b2_3c",
+			want: ErrorCodeComponents{Domain: "1A", TypeOf: "A1", Subject: "B2", Variant: "3C"},
+		},
+		{
+			name: "Should work - required components",
+			code: "ERR_A1_B2",
+			want: ErrorCodeComponents{TypeOf: "A1", Subject: "B2"},
+		},
+		{
+			name:    "Should fail - not structured",
+			code:    "E1010",
+			wantErr: true,
+		},
+		{
+			name:    "Should fail - invalid",
+			code:    "ERR_A1_B2_C3",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseErrorCode(tt.code)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrErrorCodeInvalidCode)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCatalog_components(t *testing.T) {
+	catalog := MustNewCatalog("myapp", WithCodePrefix()).
+		MustSet("1A_ERR_A1_B2", "invalid user").
+		MustSet("1A_ERR_A1_B3", "invalid invoice").
+		MustSet("2A_ERR_A1_B2", "invalid payment").
+		MustSet("1A_ERR_A2_B2_3C", "user not found").
+		MustSet("E1010", "invalid response")
+
+	cE, err := catalog.GetFrom(ErrorCodeComponents{Domain: "1a", TypeOf: "a2", Subject: "b2", Variant: "3c"})
+
+	if assert.NoError(t, err) {
+		assert.EqualError(t, cE, "MYAPP_1A_ERR_A2_B2_3C: user not found")
+	}
+
+	_, err = catalog.GetFrom(ErrorCodeComponents{TypeOf: "A1"})
+
+	assert.ErrorIs(t, err, ErrErrorCodeInvalidCode)
+
+	assert.Equal(t, []string{
+		"MYAPP_1A_ERR_A1_B2",
+		"MYAPP_1A_ERR_A1_B3",
+		"MYAPP_2A_ERR_A1_B2",
+	}, catalog.Query(ByComponents(ErrorCodeComponents{TypeOf: "A1"})))
+
+	assert.Equal(t, []string{
+		"MYAPP_1A_ERR_A1_B2",
+		"MYAPP_1A_ERR_A2_B2_3C",
+	}, catalog.Query(ByComponents(ErrorCodeComponents{Domain: "1A", Subject: "B2"})))
+}
@@ -97,22 +97,28 @@ func ExampleNew_is() {
 	// true
 }
 
-// Demonstrates JSON marshalling of custom errors.
+// Demonstrates JSON marshalling of custom errors. By default, it's safe to be
+// sent to clients: the wrapped error isn't included. Internally, it's included
+// as "cause".
 func ExampleNew_marshalJSON() {
-	// New buffer string.
-	var buf strings.Builder
-
 	errA := NewMissingError("id")
-	errB := NewMissingError("name", WithError(errA))
 
-	if err := json.NewEncoder(&buf).Encode(errB); err != nil {
+	publicJSON, err := json.Marshal(NewMissingError("name", WithError(errA)))
+	if err != nil {
 		panic(err)
 	}
 
-	fmt.Println(strings.Contains(buf.String(), `message":"missing name. Original Error: missing id`))
+	internalJSON, err := json.Marshal(NewMissingError("name", WithError(errA), WithRenderMode(RenderInternal)))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(string(publicJSON))
+	fmt.Println(string(internalJSON))
 
 	// output:
-	// true
+	// {"kind":"missing","message":"missing name","severity":"warning"}
+	// {"cause":"missing id","kind":"missing","message":"missing name","severity":"warning"}
 }
 
 // Demonstrates the WithIgnoreString option.
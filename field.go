@@ -0,0 +1,260 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+type (
+	// Field is a key/value pair which enhances the error with structured
+	// information.
+	Field struct {
+		// Key of the field.
+		Key string
+
+		// Value of the field.
+		Value any
+	}
+
+	// FieldKey is a typed field key. It's used to set the field value with
+	// its type checked (`WithTypedField`), and to retrieve it without type
+	// assertions, e.g.:
+	//
+	//	const UserID customerror.FieldKey[int] = "userID"
+	//
+	//	err := customerror.New("user not allowed", customerror.WithTypedField(UserID, 42))
+	//
+	//	id, ok := UserID.Get(err) // 42, true
+	FieldKey[T any] string
+
+	// Fields is an insertion-ordered list of fields. The zero value is an
+	// empty list, ready to use.
+	//
+	// Fields is copy-on-write: no method mutates the underlying storage, so
+	// it's safely shared between a factory error and every error created from
+	// it, without locking, and without copying on every `New`.
+	Fields struct {
+		list []Field
+	}
+)
+
+//////
+// Methods.
+//////
+
+// Len returns the number of fields.
+func (f Fields) Len() int {
+	return len(f.list)
+}
+
+// Load returns the value stored under `key`, if any.
+func (f Fields) Load(key string) (any, bool) {
+	for _, field := range f.list {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Range calls `fn` for each field, in insertion order. If `fn` returns false,
+// iteration stops.
+func (f Fields) Range(fn func(key string, value any) bool) {
+	for _, field := range f.list {
+		if !fn(field.Key, field.Value) {
+			return
+		}
+	}
+}
+
+// Keys returns the field keys, in insertion order.
+func (f Fields) Keys() []string {
+	keys := make([]string, 0, len(f.list))
+
+	for _, field := range f.list {
+		keys = append(keys, field.Key)
+	}
+
+	return keys
+}
+
+// ToMap returns the fields as a regular map.
+func (f Fields) ToMap() map[string]any {
+	m := make(map[string]any, len(f.list))
+
+	for _, field := range f.list {
+		m[field.Key] = field.Value
+	}
+
+	return m
+}
+
+// With returns a copy of the fields with `key` set to `value`. An existing key
+// keeps its position.
+func (f Fields) With(key string, value any) Fields {
+	list := make([]Field, len(f.list), len(f.list)+1)
+
+	copy(list, f.list)
+
+	for i := range list {
+		if list[i].Key == key {
+			list[i].Value = value
+
+			return Fields{list: list}
+		}
+	}
+
+	return Fields{list: append(list, Field{Key: key, Value: value})}
+}
+
+// Merge returns the union of `f` and `other`. On conflict, `other` wins. If
+// one of them is empty, the other is returned as it is - no allocation.
+func (f Fields) Merge(other Fields) Fields {
+	if len(other.list) == 0 {
+		return f
+	}
+
+	if len(f.list) == 0 {
+		return other
+	}
+
+	list := make([]Field, len(f.list), len(f.list)+len(other.list))
+
+	copy(list, f.list)
+
+	for _, field := range other.list {
+		found := false
+
+		for i := range list {
+			if list[i].Key == field.Key {
+				list[i].Value = field.Value
+
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			list = append(list, field)
+		}
+	}
+
+	return Fields{list: list}
+}
+
+// String implements the Stringer interface.
+func (k FieldKey[T]) String() string {
+	return string(k)
+}
+
+// Get returns the value of the field from the first `CustomError` in the
+// chain of `err` which has it, see `GetField`.
+func (k FieldKey[T]) Get(err error) (T, bool) {
+	return GetField[T](err, k)
+}
+
+//////
+// Exported functionalities.
+//////
+
+// GetField returns the value of the field `key` from the first `CustomError`
+// in the chain of `err` which has it. It returns false if not found, or if
+// the value isn't of type `T`. Sensitive values are returned as they are,
+// unwrapped.
+func GetField[T any, K ~string](err error, key K) (T, bool) {
+	var (
+		value T
+		found bool
+	)
+
+	walk(err, func(cE *CustomError) bool {
+		v, ok := cE.Fields.Load(string(key))
+		if !ok {
+			return true
+		}
+
+		value, found = unredact(v).(T)
+
+		return false
+	})
+
+	return value, found
+}
+
+// FieldsAs decodes the fields of the first `CustomError` in the chain of `err`
+// into `T`, usually a struct with `json` tags. If there's no `CustomError`, it
+// returns the zero value of `T`. Sensitive values are decoded as they are,
+// unwrapped.
+func FieldsAs[T any](err error) (T, error) {
+	var (
+		fields Fields
+		target T
+	)
+
+	walk(err, func(cE *CustomError) bool {
+		fields = cE.Fields
+
+		return false
+	})
+
+	if fields.Len() == 0 {
+		return target, nil
+	}
+
+	m := make(map[string]any, fields.Len())
+
+	fields.Range(func(key string, value any) bool {
+		m[key] = unredact(value)
+
+		return true
+	})
+
+	b, mErr := json.Marshal(m)
+	if mErr != nil {
+		return target, NewFailedToError("encode fields", WithError(mErr), WithErrorCode("CE_ERR_FAILED_TO_ENCODE_FIELDS"))
+	}
+
+	if uErr := json.Unmarshal(b, &target); uErr != nil {
+		return target, NewFailedToError("decode fields", WithError(uErr), WithErrorCode("CE_ERR_FAILED_TO_DECODE_FIELDS"))
+	}
+
+	return target, nil
+}
+
+//////
+// Factory.
+//////
+
+// NewFields creates fields from a map. Keys are sorted, so the order is
+// deterministic.
+func NewFields(m map[string]any) Fields {
+	if len(m) == 0 {
+		return Fields{}
+	}
+
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	list := make([]Field, 0, len(keys))
+
+	for _, k := range keys {
+		list = append(list, Field{Key: k, Value: m[k]})
+	}
+
+	return Fields{list: list}
+}
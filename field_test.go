@@ -0,0 +1,142 @@
+package customerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testFieldKeyUserID FieldKey[int]    = "userID"
+	testFieldKeyTenant FieldKey[string] = "tenant"
+)
+
+func TestGetField(t *testing.T) {
+	inner := New("inner", WithTypedField(testFieldKeyTenant, "acme"), WithField("attempts", 3))
+	outer := New("outer", WithTypedField(testFieldKeyUserID, 42), WithError(inner))
+	wrapped := fmt.Errorf("wrapped: %w", outer)
+
+	tests := []struct {
+		name      string
+		get       func() (any, bool)
+		want      any
+		wantFound bool
+	}{
+		{
+			name:      "Should work - typed key, outer error",
+			get:       func() (any, bool) { return testFieldKeyUserID.Get(wrapped) },
+			want:      42,
+			wantFound: true,
+		},
+		{
+			name:      "Should work - typed key, inner error",
+			get:       func() (any, bool) { return testFieldKeyTenant.Get(wrapped) },
+			want:      "acme",
+			wantFound: true,
+		},
+		{
+			name:      "Should work - string key, inner error",
+			get:       func() (any, bool) { return GetField[int](wrapped, "attempts") },
+			want:      3,
+			wantFound: true,
+		},
+		{
+			name:      "Should fail - wrong type",
+			get:       func() (any, bool) { return GetField[string](wrapped, "attempts") },
+			want:      "",
+			wantFound: false,
+		},
+		{
+			name:      "Should fail - missing",
+			get:       func() (any, bool) { return GetField[string](wrapped, "missing") },
+			want:      "",
+			wantFound: false,
+		},
+		{
+			name:      "Should fail - not a custom error",
+			get:       func() (any, bool) { return GetField[string](errors.New("some error"), "tenant") },
+			want:      "",
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := tt.get()
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantFound, found)
+		})
+	}
+}
+
+func TestWithTypedField(t *testing.T) {
+	type details struct {
+		Reason string
+	}
+
+	const testFieldKeyDetails FieldKey[details] = "details"
+
+	err := New(
+		"some error",
+		WithTypedField(testFieldKeyUserID, 42),
+		WithTypedField(testFieldKeyTenant, "acme"),
+		WithTypedField(testFieldKeyDetails, details{Reason: "quota"}),
+	)
+
+	userID, ok := testFieldKeyUserID.Get(err)
+	assert.True(t, ok)
+	assert.Equal(t, 42, userID)
+
+	tenant, ok := testFieldKeyTenant.Get(err)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+
+	d, ok := testFieldKeyDetails.Get(err)
+	assert.True(t, ok)
+	assert.Equal(t, details{Reason: "quota"}, d)
+
+	assert.Equal(t, "some error. Fields: userID=42, tenant=acme, details={quota}", err.Error())
+}
+
+func TestFieldsAs(t *testing.T) {
+	type details struct {
+		Tenant string `json:"tenant"`
+		UserID int    `json:"userID"`
+	}
+
+	err := fmt.Errorf("wrapped: %w", New(
+		"user not allowed",
+		WithTypedField(testFieldKeyUserID, 42),
+		WithTypedField(testFieldKeyTenant, "acme"),
+	))
+
+	got, decodeErr := FieldsAs[details](err)
+	assert.NoError(t, decodeErr)
+	assert.Equal(t, details{Tenant: "acme", UserID: 42}, got)
+
+	got, decodeErr = FieldsAs[details](errors.New("some error"))
+	assert.NoError(t, decodeErr)
+	assert.Equal(t, details{}, got)
+
+	_, decodeErr = FieldsAs[int](err)
+	assert.Error(t, decodeErr)
+}
+
+func TestFields(t *testing.T) {
+	f := NewFields(map[string]any{"b": 2, "a": 1})
+
+	assert.Equal(t, []string{"a", "b"}, f.Keys())
+
+	f2 := f.With("c", 3).With("a", 10)
+
+	assert.Equal(t, []string{"a", "b"}, f.Keys())
+	assert.Equal(t, []string{"a", "b", "c"}, f2.Keys())
+
+	a, _ := f2.Load("a")
+	assert.Equal(t, 10, a)
+
+	merged := f.Merge(NewFields(map[string]any{"b": 20, "d": 4}))
+	assert.Equal(t, map[string]any{"a": 1, "b": 20, "d": 4}, merged.ToMap())
+}
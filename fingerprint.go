@@ -0,0 +1,48 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+//////
+// Methods.
+//////
+
+// Fingerprint returns a stable identifier of the error, which allows to group
+// occurrences of the same logical error. It's computed from:
+//
+//   - `Code`
+//   - The message before translated, so it's the same in every language
+//   - The functions of the stack trace, if captured (`WithStack`). Files, and
+//     lines aren't included, so it survives unrelated changes
+//   - The fields set with `WithFingerprintFields`, if any. Other fields, e.g.:
+//     IDs, don't change it. Sensitive values are masked before hashing.
+func (cE *CustomError) Fingerprint() string {
+	h := sha256.New()
+
+	message := cE.untranslated
+	if message == "" {
+		message = cE.Message
+	}
+
+	// NUL separates the parts, so they can't be confused.
+	fmt.Fprintf(h, "%s\x00%s\x00", cE.Code, message)
+
+	for _, frame := range cE.StackTrace() {
+		fmt.Fprintf(h, "%s\x00", frame.Function)
+	}
+
+	cE.fingerprintFields.Each(func(_ int, key string) {
+		if value, ok := cE.Fields.Load(key); ok {
+			fmt.Fprintf(h, "%s=%v\x00", key, renderValue(RenderPublic, key, value))
+		}
+	})
+
+	return hex.EncodeToString(h.Sum(nil))
+}
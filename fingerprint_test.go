@@ -0,0 +1,126 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fingerprintOf(err error) string {
+	//nolint:errorlint
+	return err.(*CustomError).Fingerprint()
+}
+
+func TestCustomError_Fingerprint(t *testing.T) {
+	userNotFound := Factory(
+		"user not found",
+		WithErrorCode("E1010"),
+		WithTranslation("pt-BR", "usuário não encontrado"),
+		WithTranslation("es", "usuario no encontrado"),
+	)
+
+	id := Factory("id", WithTranslation("pt-BR", "identificador"))
+
+	catalog := MustNewCatalog("myapp").MustSet("E1010", "user not found", WithTranslation("pt-BR", "usuário não encontrado"))
+
+	newStackError := func(message string) error {
+		return New(message, WithErrorCode("E1010"), WithStack())
+	}
+
+	stackErrors := []error{}
+
+	for i := 0; i < 2; i++ {
+		stackErrors = append(stackErrors, newStackError("user not found"))
+	}
+
+	tests := []struct {
+		name  string
+		a     error
+		b     error
+		equal bool
+	}{
+		{
+			name:  "Should be stable across translations",
+			a:     userNotFound.New(),
+			b:     userNotFound.New(WithLanguage("pt-BR")),
+			equal: true,
+		},
+		{
+			name:  "Should be stable across translations - other language",
+			a:     userNotFound.New(WithLanguage("es")),
+			b:     userNotFound.New(WithLanguage("pt-BR")),
+			equal: true,
+		},
+		{
+			name:  "Should be stable across translations - factory methods",
+			a:     id.NewInvalidError(),
+			b:     id.NewInvalidError(WithLanguage("pt-BR")),
+			equal: true,
+		},
+		{
+			name:  "Should be stable across translations - catalog",
+			a:     catalog.MustGet("E1010"),
+			b:     catalog.MustGet("E1010", WithLanguage("pt-BR")),
+			equal: true,
+		},
+		{
+			name:  "Should be stable across field values",
+			a:     userNotFound.New(WithField("id", 1)),
+			b:     userNotFound.New(WithField("id", 2), WithField("tenant", "acme")),
+			equal: true,
+		},
+		{
+			name:  "Should differ by fingerprint field values",
+			a:     userNotFound.New(WithField("id", 1), WithFingerprintFields("id")),
+			b:     userNotFound.New(WithField("id", 2), WithFingerprintFields("id")),
+			equal: false,
+		},
+		{
+			name:  "Should not differ by sensitive fingerprint field values",
+			a:     userNotFound.New(WithSensitiveField("token", "a"), WithFingerprintFields("token")),
+			b:     userNotFound.New(WithSensitiveField("token", "b"), WithFingerprintFields("token")),
+			equal: true,
+		},
+		{
+			name:  "Should differ by code",
+			a:     userNotFound.New(),
+			b:     userNotFound.New(WithErrorCode("E1011")),
+			equal: false,
+		},
+		{
+			name:  "Should differ by message",
+			a:     NewInvalidError("id"),
+			b:     NewMissingError("id"),
+			equal: false,
+		},
+		{
+			name:  "Should be stable across the same stack",
+			a:     stackErrors[0],
+			b:     stackErrors[1],
+			equal: true,
+		},
+		{
+			name:  "Should differ by stack",
+			a:     stackErrors[0],
+			b:     New("user not found", WithErrorCode("E1010"), WithStack()),
+			equal: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := fingerprintOf(tt.a), fingerprintOf(tt.b)
+
+			assert.Len(t, a, 64)
+
+			if tt.equal {
+				assert.Equal(t, a, b)
+			} else {
+				assert.NotEqual(t, a, b)
+			}
+		})
+	}
+}
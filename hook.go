@@ -0,0 +1,168 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"sort"
+	"sync"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// Hook events.
+const (
+	// OnCreate is triggered for every constructed custom error.
+	OnCreate HookEvent = "create"
+
+	// OnWrap is triggered for custom errors wrapping another error
+	// (`WithError`).
+	OnWrap HookEvent = "wrap"
+
+	// OnTranslate is triggered for translated custom errors (`WithLanguage`).
+	OnTranslate HookEvent = "translate"
+
+	// OnDeprecated is triggered for deprecated custom errors
+	// (`WithDeprecation`), including catalog errors got by an alias
+	// (`Catalog.Alias`), see `LogDeprecated`.
+	OnDeprecated HookEvent = "deprecated"
+)
+
+var (
+	hooksMu sync.RWMutex
+	hooks   []*hookEntry
+)
+
+type (
+	// HookEvent determines when a hook is called.
+	HookEvent string
+
+	// Hook is called with the final custom error, before it's returned, and
+	// before observers are notified. It can enrich the error - options can be
+	// applied to it, e.g.: `WithField("service", "api")(cE)`, forward it to a
+	// sink, or veto it: if it returns true, the error is ignored (returns nil),
+	// like `WithIgnoreFunc`, and no further hook is called.
+	//
+	// Hooks must be safe for concurrent use.
+	Hook func(cE *CustomError) (veto bool)
+
+	// HookOption allows to define hook options.
+	HookOption func(h *hookEntry)
+
+	// hookEntry is a registered hook.
+	hookEntry struct {
+		// If set, the hook is only called for errors from this catalog.
+		catalog *Catalog
+
+		event    HookEvent
+		hook     Hook
+		priority int
+	}
+)
+
+//////
+// Helpers.
+//////
+
+// hookEventsOf returns the events triggered by `cE`, in the order they are
+// triggered.
+func hookEventsOf(cE *CustomError) []HookEvent {
+	events := []HookEvent{OnCreate}
+
+	if cE.Err != nil {
+		events = append(events, OnWrap)
+	}
+
+	if cE.language != "" {
+		events = append(events, OnTranslate)
+	}
+
+	if cE.deprecation != nil {
+		events = append(events, OnDeprecated)
+	}
+
+	return events
+}
+
+// runHooks calls the hooks matching `cE`. It returns true if `cE` was vetoed.
+//
+// Hooks are called per event: `OnCreate`, then `OnWrap`, then `OnTranslate`,
+// then `OnDeprecated`.
+// Within an event, by priority (lowest first), then by registration order.
+func runHooks(cE *CustomError) bool {
+	hooksMu.RLock()
+	registered := hooks
+	hooksMu.RUnlock()
+
+	if len(registered) == 0 {
+		return false
+	}
+
+	for _, event := range hookEventsOf(cE) {
+		for _, entry := range registered {
+			if entry.event != event {
+				continue
+			}
+
+			if entry.catalog != nil && entry.catalog != cE.catalog {
+				continue
+			}
+
+			if entry.hook(cE) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+//////
+// Exported functionalities.
+//////
+
+// RegisterHook registers a hook for `event`, see `Hook`. Hooks registered
+// from within a hook take effect on the next error. It returns a function
+// which unregisters it.
+func RegisterHook(event HookEvent, hook Hook, opts ...HookOption) (unregister func()) {
+	entry := &hookEntry{event: event, hook: hook}
+
+	// Apply options.
+	for _, opt := range opts {
+		opt(entry)
+	}
+
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	// Registered hooks are never changed in place, a running `runHooks` may
+	// be using them.
+	final := make([]*hookEntry, 0, len(hooks)+1)
+
+	final = append(final, hooks...)
+	final = append(final, entry)
+
+	sort.SliceStable(final, func(i, j int) bool {
+		return final[i].priority < final[j].priority
+	})
+
+	hooks = final
+
+	return func() {
+		hooksMu.Lock()
+		defer hooksMu.Unlock()
+
+		final := make([]*hookEntry, 0, len(hooks))
+
+		for _, e := range hooks {
+			if e != entry {
+				final = append(final, e)
+			}
+		}
+
+		hooks = final
+	}
+}
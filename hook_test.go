@@ -0,0 +1,185 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterHook(t *testing.T) {
+	catalog := MustNewCatalog("myapp").MustSet("E1010", "invalid response")
+	otherCatalog := MustNewCatalog("otherapp").MustSet("E1010", "invalid response")
+
+	type hook struct {
+		event HookEvent
+		name  string
+		opts  []HookOption
+		veto  bool
+	}
+
+	tests := []struct {
+		name       string
+		hooks      []hook
+		construct  func() error
+		wantCalled []string
+		wantNil    bool
+	}{
+		{
+			name:       "Should call OnCreate hooks in registration order",
+			hooks:      []hook{{event: OnCreate, name: "a"}, {event: OnCreate, name: "b"}},
+			construct:  func() error { return New("some error") },
+			wantCalled: []string{"a", "b"},
+		},
+		{
+			name: "Should call hooks by priority",
+			hooks: []hook{
+				{event: OnCreate, name: "a", opts: []HookOption{WithHookPriority(10)}},
+				{event: OnCreate, name: "b"},
+				{event: OnCreate, name: "c", opts: []HookOption{WithHookPriority(-10)}},
+			},
+			construct:  func() error { return NewInvalidError("id") },
+			wantCalled: []string{"c", "b", "a"},
+		},
+		{
+			name:       "Should call hooks by event",
+			hooks:      []hook{{event: OnTranslate, name: "t"}, {event: OnWrap, name: "w"}, {event: OnCreate, name: "c"}},
+			construct:  func() error { return New("some error", WithError(errors.New("cause"))) },
+			wantCalled: []string{"c", "w"},
+		},
+		{
+			name:  "Should call OnTranslate hooks",
+			hooks: []hook{{event: OnTranslate, name: "t"}, {event: OnCreate, name: "c"}},
+			construct: func() error {
+				return Factory("some error", WithTranslation("pt-BR", "algum erro")).New(WithLanguage("pt-BR"))
+			},
+			wantCalled: []string{"c", "t"},
+		},
+		{
+			name:       "Should veto",
+			hooks:      []hook{{event: OnCreate, name: "a", veto: true}, {event: OnCreate, name: "b"}},
+			construct:  func() error { return NewMissingError("id") },
+			wantCalled: []string{"a"},
+			wantNil:    true,
+		},
+		{
+			name: "Should call catalog scoped hooks only for its errors",
+			hooks: []hook{
+				{event: OnCreate, name: "scoped", opts: []HookOption{WithHookCatalog(catalog)}},
+				{event: OnCreate, name: "global"},
+			},
+			construct: func() error {
+				if _, err := otherCatalog.Emit("E1010"); err != nil {
+					return err
+				}
+
+				return catalog.MustGet("E1010").NewInvalidError()
+			},
+			wantCalled: []string{"global", "scoped", "global"},
+		},
+		{
+			name:       "Should veto catalog errors",
+			hooks:      []hook{{event: OnCreate, name: "a", veto: true, opts: []HookOption{WithHookCatalog(catalog)}}},
+			construct:  func() error { return catalog.MustGet("E1010").New() },
+			wantCalled: []string{"a"},
+			wantNil:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called []string
+
+			for _, h := range tt.hooks {
+				h := h
+
+				unregister := RegisterHook(h.event, func(cE *CustomError) bool {
+					called = append(called, h.name)
+
+					return h.veto
+				}, h.opts...)
+
+				defer unregister()
+			}
+
+			err := tt.construct()
+
+			assert.Equal(t, tt.wantCalled, called)
+			assert.Equal(t, tt.wantNil, err == nil)
+		})
+	}
+}
+
+func TestRegisterHook_enrich(t *testing.T) {
+	unregister := RegisterHook(OnCreate, func(cE *CustomError) bool {
+		WithField("service", "api")(cE)
+
+		return false
+	})
+
+	err := NewInvalidError("id")
+
+	unregister()
+
+	assert.EqualError(t, err, "invalid id. Fields: service=api")
+
+	assert.EqualError(t, NewInvalidError("id"), "invalid id")
+}
+
+func TestRegisterHook_once(t *testing.T) {
+	catalog := MustNewCatalog("myapp").
+		MustSet("E1010", "id").
+		MustAlias("E1010", "E0010")
+
+	tests := []struct {
+		name      string
+		construct func() error
+		want      string
+	}{
+		{
+			name:      "Should run once - catalog Get, then factory method",
+			construct: func() error { return catalog.MustGet("E1010").NewInvalidError() },
+			want:      "invalid id. Fields: runs=1",
+		},
+		{
+			name:      "Should run once - alias, then factory method",
+			construct: func() error { return catalog.MustGet("E0010").NewMissingError() },
+			want:      "missing id. Fields: runs=1",
+		},
+		{
+			name: "Should run once - catalog Emit",
+			construct: func() error {
+				cE, err := catalog.Emit("E1010")
+				if err != nil {
+					return err
+				}
+
+				return cE
+			},
+			want: "id. Fields: runs=1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := 0
+
+			unregister := RegisterHook(OnCreate, func(cE *CustomError) bool {
+				runs++
+
+				WithField("runs", runs)(cE)
+
+				return false
+			})
+
+			err := tt.construct()
+
+			unregister()
+
+			assert.Equal(t, 1, runs)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// ProblemTypeDefault is the problem type of problem details, see
+// `MarshalProblemJSON`.
+const ProblemTypeDefault = "about:blank"
+
+//////
+// Helpers.
+//////
+
+// writeHTTP responds to an HTTP request with `err`, rendered by `marshal` as
+// `contentType` - or `fallback`, if it fails -, see `WriteHTTP`.
+func writeHTTP(
+	w http.ResponseWriter,
+	err error,
+	contentType string,
+	marshal func(cE *CustomError) ([]byte, error),
+	fallback string,
+) {
+	var cE *CustomError
+	if !errors.As(err, &cE) {
+		cE = NewHTTPError(http.StatusInternalServerError, WithError(err)).(*CustomError)
+	}
+
+	statusCode := cE.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusInternalServerError
+	}
+
+	if after, ok := RetryAfter(err); ok && IsRetryable(err) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(after.Seconds()))))
+	}
+
+	b, mErr := marshal(cE)
+	if mErr != nil {
+		statusCode = http.StatusInternalServerError
+
+		b = []byte(fallback)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+
+	_, _ = w.Write(b)
+}
+
+//////
+// Methods.
+//////
+
+// MarshalProblemJSON returns the problem details (RFC 9457) rendering of the
+// error: its message is the "detail", its status code the "status", and the
+// status text the "title". The other members - code, severity, tags, fields,
+// and cause - are rendered as in `MarshalJSON`. There's no "message" member,
+// so a field named so is rendered. Its schema is `ProblemJSONSchema`.
+//
+// SEE https://www.rfc-editor.org/rfc/rfc9457
+func (cE *CustomError) MarshalProblemJSON() ([]byte, error) {
+	temp := cE.wireFormat(reservedProblemMembers)
+
+	temp[memberDetail] = cE.Message
+	temp[memberType] = ProblemTypeDefault
+
+	if cE.StatusCode != 0 {
+		temp[memberStatus] = cE.StatusCode
+
+		if title := http.StatusText(cE.StatusCode); title != "" {
+			temp[memberTitle] = title
+		}
+	}
+
+	return json.Marshal(temp)
+}
+
+//////
+// Exported functionalities.
+//////
+
+// WriteHTTP responds to an HTTP request with `err`: its status code (default
+// `500`), its public JSON rendering, and - if retryable, with a suggested
+// backoff - the `Retry-After` header. Errors which aren't `CustomError` are
+// wrapped in an `500` one, not exposing them.
+func WriteHTTP(w http.ResponseWriter, err error) {
+	writeHTTP(w, err, "application/json; charset=utf-8", (*CustomError).MarshalJSON, `{"message":"internal server error"}`)
+}
+
+// WriteHTTPProblem is like `WriteHTTP`, but responds with problem details
+// (`application/problem+json`), see `MarshalProblemJSON`.
+func WriteHTTPProblem(w http.ResponseWriter, err error) {
+	writeHTTP(
+		w,
+		err,
+		"application/problem+json",
+		(*CustomError).MarshalProblemJSON,
+		`{"detail":"internal server error","status":500,"title":"Internal Server Error","type":"about:blank"}`,
+	)
+}
@@ -0,0 +1,99 @@
+package customerror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatusCode int
+		wantRetryAfter string
+		wantBody       string
+	}{
+		{
+			name:           "Should work",
+			err:            NewNotFoundError("host", WithErrorCode("E1010"), WithError(errors.New("sql: no rows in result set"))),
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"code":"E1010","kind":"not found","message":"host not found","severity":"warning"}`,
+		},
+		{
+			name:           "Should work - retry after",
+			err:            fmt.Errorf("wrapped: %w", NewHTTPError(http.StatusServiceUnavailable, WithRetryAfter(1500*time.Millisecond))),
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantRetryAfter: "2",
+			wantBody:       `{"message":"service unavailable","severity":"error"}`,
+		},
+		{
+			name:           "Should work - not retryable, no header",
+			err:            NewHTTPError(http.StatusServiceUnavailable, WithRetryAfter(time.Second), WithRetryable(false)),
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantBody:       `{"message":"service unavailable","severity":"error"}`,
+		},
+		{
+			name:           "Should work - not a custom error",
+			err:            errors.New("pq: password authentication failed"),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"message":"internal server error","severity":"error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			WriteHTTP(rec, tt.err)
+
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+			assert.Equal(t, tt.wantRetryAfter, rec.Header().Get("Retry-After"))
+			assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestWriteHTTPProblem(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "Should work",
+			err:            NewNotFoundError("host", WithErrorCode("E1010"), WithField("detail", "overridden")),
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"code":"E1010","detail":"host not found","kind":"not found","severity":"warning","status":404,"title":"Not Found","type":"about:blank"}`,
+		},
+		{
+			name:           "Should work - message field",
+			err:            NewNotFoundError("host", WithErrorCode("E1010"), WithField("message", "a field")),
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"code":"E1010","detail":"host not found","kind":"not found","message":"a field","severity":"warning","status":404,"title":"Not Found","type":"about:blank"}`,
+		},
+		{
+			name:           "Should work - not a custom error",
+			err:            errors.New("pq: password authentication failed"),
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"detail":"internal server error","severity":"error","status":500,"title":"Internal Server Error","type":"about:blank"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			WriteHTTPProblem(rec, tt.err)
+
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+			assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
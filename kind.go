@@ -0,0 +1,71 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+//////
+// Methods.
+//////
+
+// Kind returns the type of the error, e.g.: `NotFound`, if any. Built-in
+// errors, and factory methods set it, see also `WithErrorType`.
+func (cE *CustomError) Kind() ErrorType {
+	return cE.errorType
+}
+
+//////
+// Exported functionalities.
+//////
+
+// KindOf returns the type of the outermost `CustomError` in the chain of
+// `err` which has one, if any.
+func KindOf(err error) ErrorType {
+	var kind ErrorType
+
+	walk(err, func(cE *CustomError) bool {
+		kind = cE.errorType
+
+		return kind == ""
+	})
+
+	return kind
+}
+
+// IsKind returns true if any `CustomError` in the chain of `err` is of type
+// `kind`.
+func IsKind(err error, kind ErrorType) bool {
+	return !walk(err, func(cE *CustomError) bool {
+		return cE.errorType != kind
+	})
+}
+
+// IsFailedTo returns true if any error in the chain of `err` is a `FailedTo`
+// one, see `NewFailedToError`.
+func IsFailedTo(err error) bool {
+	return IsKind(err, FailedTo)
+}
+
+// IsInvalid returns true if any error in the chain of `err` is an `Invalid`
+// one, see `NewInvalidError`.
+func IsInvalid(err error) bool {
+	return IsKind(err, Invalid)
+}
+
+// IsMissing returns true if any error in the chain of `err` is a `Missing`
+// one, see `NewMissingError`.
+func IsMissing(err error) bool {
+	return IsKind(err, Missing)
+}
+
+// IsNotFound returns true if any error in the chain of `err` is a `NotFound`
+// one, see `NewNotFoundError`.
+func IsNotFound(err error) bool {
+	return IsKind(err, NotFound)
+}
+
+// IsRequired returns true if any error in the chain of `err` is a `Required`
+// one, see `NewRequiredError`.
+func IsRequired(err error) bool {
+	return IsKind(err, Required)
+}
@@ -0,0 +1,124 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindOf(t *testing.T) {
+	const Conflict ErrorType = "conflict"
+
+	id := Factory("id", WithTranslation("pt-BR", "identificador"))
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorType
+		is   func(err error) bool
+	}{
+		{
+			name: "Should work - failed to",
+			err:  NewFailedToError("create user"),
+			want: FailedTo,
+			is:   IsFailedTo,
+		},
+		{
+			name: "Should work - invalid",
+			err:  NewInvalidError("id"),
+			want: Invalid,
+			is:   IsInvalid,
+		},
+		{
+			name: "Should work - missing",
+			err:  NewMissingError("id"),
+			want: Missing,
+			is:   IsMissing,
+		},
+		{
+			name: "Should work - not found",
+			err:  NewNotFoundError("user"),
+			want: NotFound,
+			is:   IsNotFound,
+		},
+		{
+			name: "Should work - required",
+			err:  NewRequiredError("id"),
+			want: Required,
+			is:   IsRequired,
+		},
+		{
+			name: "Should work - factory method",
+			err:  id.NewInvalidError(),
+			want: Invalid,
+			is:   IsInvalid,
+		},
+		{
+			name: "Should work - factory method, translated",
+			err:  id.NewMissingError(WithLanguage("pt-BR")),
+			want: Missing,
+			is:   IsMissing,
+		},
+		{
+			name: "Should work - custom",
+			err:  New("user already exists", WithErrorType(Conflict)),
+			want: Conflict,
+			is:   func(err error) bool { return IsKind(err, Conflict) },
+		},
+		{
+			name: "Should work - wrapped",
+			err:  fmt.Errorf("get user: %w", NewNotFoundError("user")),
+			want: NotFound,
+			is:   IsNotFound,
+		},
+		{
+			name: "Should work - outermost wins, predicates walk the chain",
+			err:  NewFailedToError("get user", WithError(NewNotFoundError("user"))),
+			want: FailedTo,
+			is:   IsNotFound,
+		},
+		{
+			name: "Should work - kindless outer error",
+			err:  New("get user", WithError(NewNotFoundError("user"))),
+			want: NotFound,
+			is:   IsNotFound,
+		},
+		{
+			name: "Should work - none",
+			err:  NewHTTPError(http.StatusNotFound),
+			want: "",
+			is:   func(err error) bool { return !IsNotFound(err) },
+		},
+		{
+			name: "Should work - not a custom error",
+			err:  errors.New("not found"),
+			want: "",
+			is:   func(err error) bool { return !IsNotFound(err) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, KindOf(tt.err))
+			assert.True(t, tt.is(tt.err))
+		})
+	}
+}
+
+func TestCustomError_Kind(t *testing.T) {
+	//nolint:errorlint
+	cE := NewNotFoundError("user", WithErrorCode("E1010")).(*CustomError)
+
+	assert.Equal(t, NotFound, cE.Kind())
+
+	b, err := cE.MarshalJSON()
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"code":"E1010","kind":"not found","message":"user not found","severity":"warning"}`, string(b))
+}
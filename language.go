@@ -3,7 +3,6 @@ package customerror
 import (
 	"regexp"
 	"strings"
-	"sync"
 )
 
 //////
@@ -51,8 +50,9 @@ type (
 	// Language is a language code.
 	Language string
 
-	// LanguageMessageMap is a map of language codes to error messages.
-	LanguageMessageMap = *sync.Map
+	// LanguageMessageMap is a map of language codes to error messages. It's
+	// copy-on-write: once set, it's never mutated, only replaced.
+	LanguageMessageMap map[Language]string
 )
 
 //////
@@ -73,6 +73,43 @@ func (l Language) Validate() error {
 	return nil
 }
 
+// With returns a copy of the map with `message` set for `lang`.
+func (m LanguageMessageMap) With(lang Language, message string) LanguageMessageMap {
+	final := make(LanguageMessageMap, len(m)+1)
+
+	for k, v := range m {
+		final[k] = v
+	}
+
+	final[lang] = message
+
+	return final
+}
+
+// Merge returns the union of `m` and `other`. On conflict, `other` wins. If one
+// of them is empty, the other is returned as it is - no allocation.
+func (m LanguageMessageMap) Merge(other LanguageMessageMap) LanguageMessageMap {
+	if len(other) == 0 {
+		return m
+	}
+
+	if len(m) == 0 {
+		return other
+	}
+
+	final := make(LanguageMessageMap, len(m)+len(other))
+
+	for k, v := range m {
+		final[k] = v
+	}
+
+	for k, v := range other {
+		final[k] = v
+	}
+
+	return final
+}
+
 // GetRoot returns the root language code. Given "en-US", it returns "en".
 func (l Language) GetRoot() string {
 	matches := LanguageRegex.FindStringSubmatch(l.String())
@@ -19,6 +19,15 @@ const (
 	Required ErrorType = "required"
 )
 
+// builtInTemplates are the default English templates of built-in errors.
+var builtInTemplates = map[ErrorType]string{
+	FailedTo: "failed to %s",
+	Invalid:  "invalid %s",
+	Missing:  "missing %s",
+	NotFound: "%s not found",
+	Required: "%s required",
+}
+
 // Singleton.
 var (
 	once                      sync.Once
@@ -48,6 +57,183 @@ type (
 	ErrorPrefixMap = *sync.Map
 )
 
+//////
+// Helpers.
+//////
+
+// templateIn returns the template for `lang`, and `errorType` in `m`, if any.
+func templateIn(m LanguageErrorMap, lang Language, errorType ErrorType) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+
+	errorTypeMap, ok := m.Load(lang)
+	if !ok {
+		return "", false
+	}
+
+	template, ok := errorTypeMap.(ErrorPrefixMap).Load(errorType)
+	if !ok {
+		return "", false
+	}
+
+	return template.(string), true
+}
+
+// rangeTemplates calls `fn` for each template in `m`.
+func rangeTemplates(m LanguageErrorMap, fn func(lang Language, errorType ErrorType, template string)) {
+	if m == nil {
+		return
+	}
+
+	m.Range(func(lang, errorTypeMap any) bool {
+		errorTypeMap.(ErrorPrefixMap).Range(func(errorType, template any) bool {
+			fn(lang.(Language), errorType.(ErrorType), template.(string))
+
+			return true
+		})
+
+		return true
+	})
+}
+
+// withTemplate returns a copy of `m` with the template for `lang`, and
+// `errorType`. Template maps of errors are copy-on-write: once set, they are
+// never mutated, only replaced, so they can be shared.
+func withTemplate(m LanguageErrorMap, lang Language, errorType ErrorType, template string) LanguageErrorMap {
+	final := &sync.Map{}
+
+	store := func(lang Language, errorType ErrorType, template string) {
+		errorTypeMap, _ := final.LoadOrStore(lang, &sync.Map{})
+
+		errorTypeMap.(ErrorPrefixMap).Store(errorType, template)
+	}
+
+	rangeTemplates(m, store)
+
+	store(lang, errorType, template)
+
+	return final
+}
+
+// mergeTemplates returns the templates of `m`, and `other` merged. On
+// conflict, `other` wins.
+func mergeTemplates(m, other LanguageErrorMap) LanguageErrorMap {
+	if other == nil {
+		return m
+	}
+
+	if m == nil {
+		return other
+	}
+
+	final := m
+
+	rangeTemplates(other, func(lang Language, errorType ErrorType, template string) {
+		final = withTemplate(final, lang, errorType, template)
+	})
+
+	return final
+}
+
+// withErrorTypeTemplates merges `m` into the templates of the error. Existing
+// ones win.
+func withErrorTypeTemplates(m LanguageErrorMap) Option {
+	return func(cE *CustomError) {
+		cE.LanguageErrorTypeMap = mergeTemplates(m, cE.LanguageErrorTypeMap)
+	}
+}
+
+// unprefix returns `message` without `template`, if prefixed with it.
+func unprefix(template, message string) (string, bool) {
+	prefix, suffix, _ := strings.Cut(template, "%s")
+
+	if len(message) < len(prefix)+len(suffix) ||
+		!strings.HasPrefix(message, prefix) ||
+		!strings.HasSuffix(message, suffix) {
+		return "", false
+	}
+
+	return message[len(prefix) : len(message)-len(suffix)], true
+}
+
+// reprefix re-prefixes the message of built-in errors, prefixed with their
+// default template, if the error has its own English template, see
+// `WithErrorTypeTemplate`. It must be the last option - other options see the
+// message prefixed with the default template. Translated messages aren't
+// prefixed - translations are the whole message.
+//
+// NOTE: It doesn't look up global templates, built-in errors are used to
+// initialize them.
+func reprefix(cE *CustomError) {
+	template := builtInTemplates[cE.errorType]
+
+	final, ok := templateIn(cE.LanguageErrorTypeMap, English, cE.errorType)
+	if !ok || template == "" || final == template {
+		return
+	}
+
+	for _, message := range []*string{&cE.Message, &cE.untranslated} {
+		if raw, ok := unprefix(template, *message); ok {
+			*message = fmt.Sprintf(final, raw)
+		}
+	}
+}
+
+// template is like `Template`, for a valid language. The root is only
+// computed if needed.
+func (cE *CustomError) template(l Language, errorType ErrorType) (string, error) {
+	if template, ok := templateIn(cE.LanguageErrorTypeMap, l, errorType); ok {
+		return template, nil
+	}
+
+	root := ""
+
+	if cE.LanguageErrorTypeMap != nil {
+		root = l.GetRoot()
+
+		if template, ok := templateIn(cE.LanguageErrorTypeMap, Language(root), errorType); ok {
+			return template, nil
+		}
+	}
+
+	if template, err := GetTemplate(l.String(), errorType.String()); err == nil {
+		return template, nil
+	}
+
+	if root == "" {
+		root = l.GetRoot()
+	}
+
+	if root == "" || root == l.String() {
+		return "", ErrTemplateNotFound
+	}
+
+	return GetTemplate(root, errorType.String())
+}
+
+// templateOptions returns `opts`, preceded by the templates of the error, if
+// any, so built-in errors built from it use them.
+func (cE *CustomError) templateOptions(opts []Option) []Option {
+	if cE.LanguageErrorTypeMap == nil {
+		return opts
+	}
+
+	return prependOptions(opts, withErrorTypeTemplates(cE.LanguageErrorTypeMap))
+}
+
+// builtInOptions returns the options of built-in errors of `errorType`: the
+// defaults, then `opts`, then `reprefix`.
+func builtInOptions(errorType ErrorType, opts []Option, defaults ...Option) []Option {
+	final := make([]Option, 0, len(defaults)+len(opts)+2)
+
+	final = append(final, WithErrorType(errorType))
+	final = append(final, defaults...)
+	final = append(final, opts...)
+
+	return append(final, reprefix)
+}
+
 //////
 // Methods.
 //////
@@ -57,6 +243,19 @@ func (e ErrorType) String() string {
 	return string(e)
 }
 
+// Template returns the template for `language`, and `errorType`, e.g.:
+// "invalid %s". The templates of the error win over the global ones (see
+// `WithErrorTypeTemplate`, and `SetErrorPrefixMap`). If `language` has no
+// template, its root (e.g.: "pt" for "pt-BR") is used.
+func (cE *CustomError) Template(language string, errorType ErrorType) (string, error) {
+	l, err := NewLanguage(language)
+	if err != nil {
+		return "", err
+	}
+
+	return cE.template(l, errorType)
+}
+
 //////
 // Exported functionalities.
 //////
@@ -2,6 +2,8 @@ package customerror
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGetTemplate(t *testing.T) {
@@ -137,3 +139,152 @@ func TestSetErrorTypePrefixTemplateMap(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomError_Template(t *testing.T) {
+	cE := Factory(
+		"id",
+		WithErrorTypeTemplate("en", Invalid, "%s is invalid"),
+		WithErrorTypeTemplate("pt", Invalid, "%s é inválido"),
+	)
+
+	tests := []struct {
+		name      string
+		language  string
+		errorType ErrorType
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "Should work - error",
+			language:  "en",
+			errorType: Invalid,
+			want:      "%s is invalid",
+		},
+		{
+			name:      "Should work - error, root",
+			language:  "pt-BR",
+			errorType: Invalid,
+			want:      "%s é inválido",
+		},
+		{
+			name:      "Should work - global",
+			language:  "es",
+			errorType: Invalid,
+			want:      "%s inválido",
+		},
+		{
+			name:      "Should work - global, root",
+			language:  "es-AR",
+			errorType: Missing,
+			want:      "falta %s",
+		},
+		{
+			name:      "Should fail - unknown",
+			language:  "en",
+			errorType: "unknown",
+			wantErr:   true,
+		},
+		{
+			name:     "Should fail - invalid language",
+			language: "english",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cE.Template(tt.language, tt.errorType)
+
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWithErrorTypeTemplate(t *testing.T) {
+	factory := Factory(
+		"id",
+		WithErrorTypeTemplate("en", Invalid, "%s is invalid"),
+		WithTranslation("es", "id"),
+	)
+
+	catalog := MustNewCatalog(
+		"myapp",
+		WithCatalogErrorTypeTemplate("en", Invalid, "%s isn't valid"),
+		WithCatalogErrorTypeTemplate("en", Missing, "%s is missing"),
+	).
+		MustSet("E1010", "id").
+		MustSet("E1011", "name", WithErrorTypeTemplate("en", Missing, "no %s"))
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "Should work - built-in",
+			err:  NewInvalidError("id", WithErrorTypeTemplate("en", Invalid, "%s is invalid")),
+			want: "id is invalid",
+		},
+		{
+			name: "Should work - built-in, other type",
+			err:  NewMissingError("id", WithErrorTypeTemplate("en", Invalid, "%s is invalid")),
+			want: "missing id",
+		},
+		{
+			name: "Should work - built-in, translated",
+			err:  NewInvalidError("id", WithTranslation("es", "id inválido"), WithLanguage("es"), WithErrorTypeTemplate("en", Invalid, "%s is invalid")),
+			want: "id inválido",
+		},
+		{
+			name: "Should work - factory",
+			err:  factory.NewInvalidError(),
+			want: "id is invalid",
+		},
+		{
+			name: "Should work - factory, option wins",
+			err:  factory.NewInvalidError(WithErrorTypeTemplate("en", Invalid, "bad %s")),
+			want: "bad id",
+		},
+		{
+			name: "Should work - factory, translated",
+			err:  factory.NewInvalidError(WithLanguage("es")),
+			want: "id inválido",
+		},
+		{
+			name: "Should work - factory, translated, error template",
+			err:  factory.NewInvalidError(WithErrorTypeTemplate("es", Invalid, "%s no es válido"), WithLanguage("es")),
+			want: "id no es válido",
+		},
+		{
+			name: "Should work - catalog",
+			err:  catalog.MustGet("E1010").NewInvalidError(),
+			want: "id isn't valid",
+		},
+		{
+			name: "Should work - catalog, error wins",
+			err:  catalog.MustGet("E1011").NewMissingError(),
+			want: "no name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.err, tt.want)
+		})
+	}
+}
+
+func TestCopy_templates(t *testing.T) {
+	src := New("some error", WithErrorTypeTemplate("en", Invalid, "%s is invalid"), WithErrorTypeTemplate("en", Missing, "no %s"))
+	target := New("other error", WithErrorTypeTemplate("en", Invalid, "bad %s"))
+
+	//nolint:errorlint,forcetypeassert
+	cE := Copy(src.(*CustomError), target.(*CustomError))
+
+	invalid, err := cE.Template("en", Invalid)
+	assert.NoError(t, err)
+	assert.Equal(t, "bad %s", invalid)
+
+	missing, err := cE.Template("en", Missing)
+	assert.NoError(t, err)
+	assert.Equal(t, "no %s", missing)
+}
@@ -0,0 +1,235 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// DefaultMapper is the mapper used by `Translate`, and `RegisterMapping`. It
+// has the built-in rules, see `NewMapper`.
+var DefaultMapper = NewMapper(WithBuiltInMappings())
+
+type (
+	// Matcher returns true if a foreign error matches a rule, see `MatchIs`,
+	// and `MatchAs`. Any predicate func is a matcher.
+	Matcher func(err error) bool
+
+	// MapFunc builds the custom error of a rule. Its signature is the same as
+	// factory methods, e.g.: `factory.NewMissingError`, see also `ToCode`.
+	// `opts` include the foreign error (`WithError`). If it returns nil, the
+	// next rule is tried.
+	MapFunc func(opts ...Option) error
+
+	// Mapper maps foreign errors, e.g.: `sql.ErrNoRows`, to custom errors. It's
+	// safe for concurrent use.
+	Mapper struct {
+		mu    sync.RWMutex
+		rules []*mapperRule
+	}
+
+	// MapperOption allows to define mapper options.
+	MapperOption func(m *Mapper)
+
+	// mapperRule is a registered rule.
+	mapperRule struct {
+		match Matcher
+		to    MapFunc
+	}
+)
+
+//////
+// Helpers.
+//////
+
+// builtInMappings returns the built-in rules, for standard library errors.
+func builtInMappings() []*mapperRule {
+	return []*mapperRule{
+		{
+			match: MatchIs(sql.ErrNoRows),
+			to: func(opts ...Option) error {
+				return NewNotFoundError("record", opts...)
+			},
+		},
+		{
+			match: MatchIs(os.ErrNotExist),
+			to: func(opts ...Option) error {
+				return NewNotFoundError("file", opts...)
+			},
+		},
+		{
+			match: MatchIs(io.ErrUnexpectedEOF),
+			to: func(opts ...Option) error {
+				return NewInvalidError("input. Unexpected end of data", opts...)
+			},
+		},
+		{
+			match: MatchAs[*net.OpError](),
+			to: func(opts ...Option) error {
+				return NewFailedToError("reach the network", prependOptions(
+					opts,
+					WithStatusCode(http.StatusServiceUnavailable),
+					WithRetryable(true),
+					WithTemporary(true),
+				)...)
+			},
+		},
+		{
+			match: MatchIs(context.DeadlineExceeded),
+			to: func(opts ...Option) error {
+				return NewFailedToError("complete before the deadline", prependOptions(
+					opts,
+					WithStatusCode(http.StatusGatewayTimeout),
+					WithRetryable(true),
+					WithTemporary(true),
+				)...)
+			},
+		},
+	}
+}
+
+//////
+// Methods.
+//////
+
+// Register registers a rule: foreign errors matching `match` are mapped with
+// `to`. Rules registered later are tried first, so they override the
+// built-in ones. It returns a function which unregisters it.
+func (m *Mapper) Register(match Matcher, to MapFunc) (unregister func()) {
+	rule := &mapperRule{match: match, to: to}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rules = append(m.rules, rule)
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		final := make([]*mapperRule, 0, len(m.rules))
+
+		for _, r := range m.rules {
+			if r != rule {
+				final = append(final, r)
+			}
+		}
+
+		m.rules = final
+	}
+}
+
+// Translate maps `err` to a custom error wrapping it (`WithError`), so
+// `errors.Is`, and `errors.As` still match the foreign error. `err` is
+// returned as is if it's nil, already a custom error - any error in its chain
+// is -, or no rule matches.
+func (m *Mapper) Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var cE *CustomError
+	if errors.As(err, &cE) {
+		return err
+	}
+
+	m.mu.RLock()
+	rules := m.rules
+	m.mu.RUnlock()
+
+	for i := len(rules) - 1; i >= 0; i-- {
+		if !rules[i].match(err) {
+			continue
+		}
+
+		if translated := rules[i].to(WithError(err)); translated != nil {
+			return translated
+		}
+	}
+
+	return err
+}
+
+//////
+// Exported functionalities.
+//////
+
+// MatchIs returns a matcher for errors which are (`errors.Is`) any of
+// `targets`.
+func MatchIs(targets ...error) Matcher {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// MatchAs returns a matcher for errors which are (`errors.As`) of type `T`,
+// e.g.: `MatchAs[*net.OpError]()`.
+func MatchAs[T error]() Matcher {
+	return func(err error) bool {
+		var target T
+
+		return errors.As(err, &target)
+	}
+}
+
+// ToCode returns a `MapFunc` which gets the error with `errorCode` from
+// `catalog`, with `opts` applied, see `Catalog.Emit`. If there's no such error,
+// the next rule is tried.
+func ToCode(catalog *Catalog, errorCode string, opts ...Option) MapFunc {
+	return func(mapOpts ...Option) error {
+		cE, err := catalog.Emit(errorCode, prependOptions(mapOpts, opts...)...)
+		if err != nil || cE == nil {
+			return nil
+		}
+
+		return cE
+	}
+}
+
+// RegisterMapping registers a rule in the `DefaultMapper`, see
+// `Mapper.Register`.
+func RegisterMapping(match Matcher, to MapFunc) (unregister func()) {
+	return DefaultMapper.Register(match, to)
+}
+
+// Translate maps `err` to a custom error with the `DefaultMapper`, see
+// `Mapper.Translate`.
+func Translate(err error) error {
+	return DefaultMapper.Translate(err)
+}
+
+//////
+// Factory.
+//////
+
+// NewMapper creates a mapper, without rules unless `WithBuiltInMappings` is
+// used.
+func NewMapper(opts ...MapperOption) *Mapper {
+	m := &Mapper{}
+
+	// Apply options.
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
@@ -0,0 +1,160 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		want           string
+		wantKind       ErrorType
+		wantRetryable  bool
+		wantStatusCode int
+	}{
+		{
+			name:           "Should translate - sql.ErrNoRows",
+			err:            fmt.Errorf("get user: %w", sql.ErrNoRows),
+			want:           "record not found. Original Error: get user: sql: no rows in result set",
+			wantKind:       NotFound,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "Should translate - os.ErrNotExist",
+			err:            &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist},
+			want:           "file not found. Original Error: open config.yaml: file does not exist",
+			wantKind:       NotFound,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "Should translate - io.ErrUnexpectedEOF",
+			err:            io.ErrUnexpectedEOF,
+			want:           "invalid input. Unexpected end of data. Original Error: unexpected EOF",
+			wantKind:       Invalid,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "Should translate - *net.OpError",
+			err:            &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			want:           "failed to reach the network. Original Error: dial tcp: connection refused",
+			wantKind:       FailedTo,
+			wantRetryable:  true,
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "Should translate - context.DeadlineExceeded",
+			err:            context.DeadlineExceeded,
+			want:           "failed to complete before the deadline. Original Error: context deadline exceeded",
+			wantKind:       FailedTo,
+			wantRetryable:  true,
+			wantStatusCode: http.StatusGatewayTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Translate(tt.err)
+
+			assert.EqualError(t, got, tt.want)
+			assert.ErrorIs(t, got, tt.err)
+			assert.True(t, IsKind(got, tt.wantKind))
+			assert.Equal(t, tt.wantRetryable, IsRetryable(got))
+
+			var cE *CustomError
+			if assert.ErrorAs(t, got, &cE) {
+				assert.Equal(t, tt.wantStatusCode, cE.StatusCode)
+			}
+		})
+	}
+}
+
+func TestTranslate_unchanged(t *testing.T) {
+	cE := NewMissingError("id")
+	foreign := errors.New("some error")
+
+	assert.NoError(t, Translate(nil))
+	assert.Equal(t, cE, Translate(cE))
+	assert.Equal(t, foreign, Translate(foreign))
+
+	wrapped := fmt.Errorf("get user: %w", NewNotFoundError("user", WithError(sql.ErrNoRows)))
+
+	assert.Equal(t, wrapped, Translate(wrapped))
+}
+
+func TestMapper_Register(t *testing.T) {
+	errUserNotFound := errors.New("user not found")
+
+	catalog := MustNewCatalog("myapp").
+		MustSet("E1010", "user not found", WithStatusCode(http.StatusNotFound))
+
+	factory := Factory("payment")
+
+	mapper := NewMapper(WithBuiltInMappings())
+
+	mapper.Register(MatchIs(errUserNotFound), ToCode(catalog, "E1010", WithTag("users")))
+	mapper.Register(MatchIs(errUserNotFound), ToCode(catalog, "E2020"))
+
+	unregister := mapper.Register(MatchIs(sql.ErrNoRows), factory.NewMissingError)
+
+	mapper.Register(func(err error) bool {
+		var opErr *net.OpError
+
+		return errors.As(err, &opErr) && opErr.Op == "dial"
+	}, factory.NewFailedToError)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "Should translate - catalog, missing code falls through",
+			err:  errUserNotFound,
+			want: "user not found. Original Error: user not found. Tags: users",
+		},
+		{
+			name: "Should translate - factory, overrides built-in",
+			err:  sql.ErrNoRows,
+			want: "missing payment. Original Error: sql: no rows in result set",
+		},
+		{
+			name: "Should translate - predicate",
+			err:  &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			want: "failed to payment. Original Error: dial tcp: connection refused",
+		},
+		{
+			name: "Should translate - built-in",
+			err:  &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset")},
+			want: "failed to reach the network. Original Error: read tcp: connection reset",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapper.Translate(tt.err)
+
+			assert.EqualError(t, got, tt.want)
+			assert.ErrorIs(t, got, tt.err)
+		})
+	}
+
+	unregister()
+
+	assert.EqualError(t, mapper.Translate(sql.ErrNoRows), "record not found. Original Error: sql: no rows in result set")
+
+	// Without built-in rules.
+	assert.Equal(t, sql.ErrNoRows, NewMapper().Translate(sql.ErrNoRows))
+}
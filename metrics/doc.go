@@ -0,0 +1,18 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package metrics counts custom errors by `Code`, `StatusCode`, and tags, and
+// exposes the counters in the Prometheus text format. Cardinality is bounded:
+// once the maximum number of series is reached, new label values are counted
+// as "other".
+//
+// Example:
+//
+//	collector := metrics.New()
+//
+//	unregister := collector.Register()
+//	defer unregister()
+//
+//	http.Handle("/metrics", collector.Handler())
+package metrics
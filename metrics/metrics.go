@@ -0,0 +1,191 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/thalesfsp/customerror"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+const (
+	// DefaultMaxSeries is the default maximum number of series per metric.
+	DefaultMaxSeries = 1000
+
+	// DefaultNamespace is the default prefix of the metric names.
+	DefaultNamespace = "customerror"
+
+	// Other is the label value used once the maximum number of series is
+	// reached.
+	Other = "other"
+
+	// contentType of the Prometheus text format.
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// labelEscaper escapes label values, as required by the Prometheus text
+// format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+type (
+	// Option allows to define collector options.
+	Option func(c *Collector)
+
+	// Collector counts custom errors. It's safe for concurrent use.
+	Collector struct {
+		errors    map[errorSeries]uint64
+		maxSeries int
+		mu        sync.Mutex
+		namespace string
+		tags      map[string]uint64
+	}
+
+	// errorSeries are the labels of the errors counter.
+	errorSeries struct {
+		code       string
+		statusCode string
+	}
+)
+
+//////
+// Options.
+//////
+
+// WithMaxSeries allows to specify the maximum number of series per metric.
+func WithMaxSeries(maxSeries int) Option {
+	return func(c *Collector) {
+		c.maxSeries = maxSeries
+	}
+}
+
+// WithNamespace allows to specify the prefix of the metric names.
+func WithNamespace(namespace string) Option {
+	return func(c *Collector) {
+		c.namespace = namespace
+	}
+}
+
+//////
+// Methods.
+//////
+
+// Observe counts `cE`. It's a `customerror.Observer`.
+func (c *Collector) Observe(cE *customerror.CustomError) {
+	series := errorSeries{code: cE.Code, statusCode: ""}
+
+	if cE.StatusCode != 0 {
+		series.statusCode = strconv.Itoa(cE.StatusCode)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.errors[series]; !ok && len(c.errors) >= c.maxSeries-1 {
+		series = errorSeries{code: Other, statusCode: Other}
+	}
+
+	c.errors[series]++
+
+	cE.Tags.Each(func(_ int, tag string) {
+		if _, ok := c.tags[tag]; !ok && len(c.tags) >= c.maxSeries-1 {
+			tag = Other
+		}
+
+		c.tags[tag]++
+	})
+}
+
+// Register registers the collector as an observer, see
+// `customerror.RegisterObserver`. It returns a function which unregisters it.
+func (c *Collector) Register() (unregister func()) {
+	return customerror.RegisterObserver(c.Observe)
+}
+
+// WriteTo writes the counters in the Prometheus text format. Series are
+// sorted, so the output is deterministic.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+
+	errorLines := make([]string, 0, len(c.errors))
+
+	for series, count := range c.errors {
+		errorLines = append(errorLines, fmt.Sprintf(
+			`%s_errors_total{code="%s",status_code="%s"} %d`,
+			c.namespace, labelEscaper.Replace(series.code), labelEscaper.Replace(series.statusCode), count,
+		))
+	}
+
+	tagLines := make([]string, 0, len(c.tags))
+
+	for tag, count := range c.tags {
+		tagLines = append(tagLines, fmt.Sprintf(`%s_error_tags_total{tag="%s"} %d`, c.namespace, labelEscaper.Replace(tag), count))
+	}
+
+	c.mu.Unlock()
+
+	sort.Strings(errorLines)
+	sort.Strings(tagLines)
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# HELP %s_errors_total Total number of errors, by code, and status code.\n", c.namespace)
+	fmt.Fprintf(&sb, "# TYPE %s_errors_total counter\n", c.namespace)
+
+	for _, line := range errorLines {
+		sb.WriteString(line + "\n")
+	}
+
+	fmt.Fprintf(&sb, "# HELP %s_error_tags_total Total number of errors, by tag.\n", c.namespace)
+	fmt.Fprintf(&sb, "# TYPE %s_error_tags_total counter\n", c.namespace)
+
+	for _, line := range tagLines {
+		sb.WriteString(line + "\n")
+	}
+
+	n, err := io.WriteString(w, sb.String())
+
+	return int64(n), err
+}
+
+// Handler returns an HTTP handler exposing the counters in the Prometheus
+// text format.
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+
+		_, _ = c.WriteTo(w)
+	})
+}
+
+//////
+// Factory.
+//////
+
+// New creates a new collector.
+func New(opts ...Option) *Collector {
+	c := &Collector{
+		errors:    map[errorSeries]uint64{},
+		maxSeries: DefaultMaxSeries,
+		namespace: DefaultNamespace,
+		tags:      map[string]uint64{},
+	}
+
+	// Apply options.
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
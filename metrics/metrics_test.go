@@ -0,0 +1,90 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalesfsp/customerror"
+)
+
+func scrape(t *testing.T, c *Collector) string {
+	t.Helper()
+
+	server := httptest.NewServer(c.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+
+	b, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+
+	return string(b)
+}
+
+func TestCollector(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "Should count by code, status code, and tag",
+			want: `# HELP customerror_errors_total Total number of errors, by code, and status code.
+# TYPE customerror_errors_total counter
+customerror_errors_total{code="",status_code=""} 1
+customerror_errors_total{code="E1010",status_code="400"} 2
+customerror_errors_total{code="E1011",status_code="404"} 1
+# HELP customerror_error_tags_total Total number of errors, by tag.
+# TYPE customerror_error_tags_total counter
+customerror_error_tags_total{tag="db"} 1
+customerror_error_tags_total{tag="say \"hi\""} 2
+`,
+		},
+		{
+			name: "Should collapse series beyond the maximum into other",
+			opts: []Option{WithMaxSeries(2), WithNamespace("app")},
+			want: `# HELP app_errors_total Total number of errors, by code, and status code.
+# TYPE app_errors_total counter
+app_errors_total{code="E1010",status_code="400"} 2
+app_errors_total{code="other",status_code="other"} 2
+# HELP app_error_tags_total Total number of errors, by tag.
+# TYPE app_error_tags_total counter
+app_error_tags_total{tag="other"} 1
+app_error_tags_total{tag="say \"hi\""} 2
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(tt.opts...)
+
+			unregister := c.Register()
+
+			_ = customerror.NewInvalidError("id", customerror.WithErrorCode("E1010"), customerror.WithTag(`say "hi"`))
+			_ = customerror.NewInvalidError("name", customerror.WithErrorCode("E1010"), customerror.WithTag(`say "hi"`))
+			_ = customerror.NewNotFoundError("user", customerror.WithErrorCode("E1011"), customerror.WithTag("db"))
+			_ = customerror.New("something happened")
+
+			unregister()
+
+			_ = customerror.New("not counted")
+
+			assert.Equal(t, tt.want, scrape(t, c))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"sync"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+var (
+	observersMu sync.RWMutex
+	observers   []*observerEntry
+)
+
+type (
+	// Observer is notified whenever a custom error is constructed: `New`, the
+	// built-in errors, the factory methods (`cE.New`, `cE.NewInvalidError`,
+	// etc.), and catalog `Emit`. It must not change the error, and must be
+	// safe for concurrent use. Factories (`Factory`, `NewChildError`), and
+	// catalog `Get` aren't observed - they are definitions, not errors.
+	Observer func(cE *CustomError)
+
+	// observerEntry allows to unregister an observer - functions aren't
+	// comparable.
+	observerEntry struct {
+		observer Observer
+	}
+)
+
+//////
+// Helpers.
+//////
+
+// notify notifies all observers about `cE`. Observers are called unlocked, so
+// they can register, and unregister observers - which take effect on the next
+// error.
+func notify(cE *CustomError) {
+	observersMu.RLock()
+	registered := observers
+	observersMu.RUnlock()
+
+	for _, entry := range registered {
+		entry.observer(cE)
+	}
+}
+
+// finalize finalizes the construction of `cE`: hooks are called, then
+// observers are notified. It returns nil if `cE` is nil (ignored), or vetoed
+// by a hook.
+func finalize(cE *CustomError) *CustomError {
+	if cE == nil {
+		return nil
+	}
+
+	if runHooks(cE) {
+		return nil
+	}
+
+	notify(cE)
+
+	return cE
+}
+
+// emit is like `finalize`, returning `cE` as an `error`.
+func emit(cE *CustomError) error {
+	if cE = finalize(cE); cE == nil {
+		return nil
+	}
+
+	return cE
+}
+
+//////
+// Exported functionalities.
+//////
+
+// RegisterObserver registers an observer, see `Observer`. Observers are
+// notified in registration order. It returns a function which unregisters it.
+func RegisterObserver(observer Observer) (unregister func()) {
+	entry := &observerEntry{observer: observer}
+
+	observersMu.Lock()
+	defer observersMu.Unlock()
+
+	observers = append(observers, entry)
+
+	return func() {
+		observersMu.Lock()
+		defer observersMu.Unlock()
+
+		final := make([]*observerEntry, 0, len(observers))
+
+		for _, e := range observers {
+			if e != entry {
+				final = append(final, e)
+			}
+		}
+
+		observers = final
+	}
+}
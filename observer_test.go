@@ -0,0 +1,124 @@
+package customerror
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterObserver(t *testing.T) {
+	catalog := MustNewCatalog("myapp").MustSet("E1010", "invalid response", WithTag("catalog"))
+	factory := Factory("insert id", WithErrorCode("E2020"))
+
+	tests := []struct {
+		name      string
+		construct func()
+		wantCodes []string
+	}{
+		{
+			name:      "Should notify - New",
+			construct: func() { _ = New("some error", WithErrorCode("E1")) },
+			wantCodes: []string{"E1"},
+		},
+		{
+			name:      "Should notify - built-in",
+			construct: func() { _ = NewMissingError("id", WithErrorCode("E2")) },
+			wantCodes: []string{"E2"},
+		},
+		{
+			name:      "Should notify once, with the final error - factory methods",
+			construct: func() { _ = factory.NewInvalidError() },
+			wantCodes: []string{"E2020"},
+		},
+		{
+			name:      "Should notify once, with the final error - factory New",
+			construct: func() { _ = factory.New() },
+			wantCodes: []string{"E2020"},
+		},
+		{
+			name:      "Should notify once, with the final error - factory NewHTTPError",
+			construct: func() { _ = factory.NewHTTPError(http.StatusNotFound) },
+			wantCodes: []string{"E2020"},
+		},
+		{
+			name:      "Should notify - catalog Emit",
+			construct: func() { _, _ = catalog.Emit("E1010") },
+			wantCodes: []string{""},
+		},
+		{
+			name:      "Should notify once - catalog Get, then factory method",
+			construct: func() { _ = catalog.MustGet("E1010").NewInvalidError() },
+			wantCodes: []string{""},
+		},
+		{
+			name:      "Should not notify - catalog Get",
+			construct: func() { _, _ = catalog.Get("E1010") },
+			wantCodes: nil,
+		},
+		{
+			name:      "Should not notify - definitions",
+			construct: func() { _ = Factory("id").NewChildError() },
+			wantCodes: nil,
+		},
+		{
+			name:      "Should not notify - ignored",
+			construct: func() { _ = New("some error", WithIgnoreString("some")) },
+			wantCodes: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var codes []string
+
+			unregister := RegisterObserver(func(cE *CustomError) {
+				codes = append(codes, cE.Code)
+			})
+
+			tt.construct()
+
+			unregister()
+
+			// Not notified after unregistered.
+			tt.construct()
+
+			assert.Equal(t, tt.wantCodes, codes)
+		})
+	}
+}
+
+func TestCatalog_Get_options(t *testing.T) {
+	catalog := MustNewCatalog("myapp").MustSet("E1010", "invalid response", WithTag("catalog"))
+
+	cE, err := catalog.Get("E1010", WithField("key", "value"), WithTag("extra"))
+	assert.NoError(t, err)
+	assert.Equal(t, "invalid response. Tags: catalog, extra. Fields: key=value", cE.Error())
+
+	// The catalog one is untouched.
+	cE, err = catalog.Get("E1010")
+	assert.NoError(t, err)
+	assert.Equal(t, "invalid response. Tags: catalog", cE.Error())
+}
+
+func TestRegisterObserver_reentrant(t *testing.T) {
+	var codes []string
+
+	var unregister func()
+
+	unregister = RegisterObserver(func(cE *CustomError) {
+		codes = append(codes, cE.Code)
+
+		// Unregisters itself, and registers another - it'd deadlock if
+		// observers were called locked.
+		unregister()
+
+		RegisterObserver(func(cE *CustomError) {
+			codes = append(codes, "other "+cE.Code)
+		})()
+	})
+
+	_ = New("some error", WithErrorCode("E1"))
+	_ = New("some error", WithErrorCode("E2"))
+
+	assert.Equal(t, []string{"E1"}, codes)
+}
@@ -14,10 +14,9 @@
 package customerror
 
 import (
+	"context"
 	"strings"
-	"sync"
-
-	"github.com/emirpasic/gods/sets/treeset"
+	"time"
 )
 
 //////
@@ -27,15 +26,16 @@ import (
 // Option allows to define error options.
 type Option func(s *CustomError)
 
-// Prepend options.
-func prependOptions(source []Option, item Option) []Option {
-	source = append(source, nil)
+// CatalogOption allows to define catalog options.
+type CatalogOption func(c *Catalog)
 
-	copy(source[1:], source)
+// Prepend options. It never changes `source`.
+func prependOptions(source []Option, items ...Option) []Option {
+	final := make([]Option, 0, len(items)+len(source))
 
-	source[0] = item
+	final = append(final, items...)
 
-	return source
+	return append(final, source...)
 }
 
 //////
@@ -103,35 +103,124 @@ func WithIgnoreString(s ...string) Option {
 // WithTag allows to specify tags for the error.
 func WithTag(tag ...string) Option {
 	return func(cE *CustomError) {
-		if cE.Tags == nil {
-			cE.Tags = &Set{treeset.NewWithStringComparator()}
-		}
-
-		for _, t := range tag {
-			cE.Tags.Add(t)
-		}
+		cE.Tags = cE.Tags.With(tag...)
 	}
 }
 
 // WithFields allows to set fields for the error.
 func WithFields(fields map[string]interface{}) Option {
 	return func(cE *CustomError) {
-		if cE.Fields == nil {
-			cE.Fields = &sync.Map{}
-		}
-
-		cE.Fields = mapToSyncMap(fields)
+		cE.Fields = NewFields(fields)
 	}
 }
 
-// WithField allows to set a field for the error.
+// WithField allows to set a field for the error. For typed keys, see
+// `WithTypedField`.
 func WithField(key string, value any) Option {
 	return func(cE *CustomError) {
-		if cE.Fields == nil {
-			cE.Fields = &sync.Map{}
+		cE.Fields = cE.Fields.With(key, value)
+	}
+}
+
+// WithTypedField allows to set a field for the error with a typed key. Unlike
+// `WithField`, `value` must be of the type of the key, so it can be retrieved
+// with `key.Get`.
+func WithTypedField[T any](key FieldKey[T], value T) Option {
+	return func(cE *CustomError) {
+		cE.Fields = cE.Fields.With(string(key), value)
+	}
+}
+
+// WithRetryable allows to specify whether the error is retryable. It takes
+// precedence over the status code, see `IsRetryable`.
+func WithRetryable(retryable bool) Option {
+	return func(cE *CustomError) {
+		cE.retry.retryable, cE.retry.retryableSet = retryable, true
+	}
+}
+
+// WithRetryAfter allows to specify the suggested backoff before retrying. It
+// also makes the error retryable.
+func WithRetryAfter(after time.Duration) Option {
+	return func(cE *CustomError) {
+		cE.retry.after = after
+		cE.retry.retryable, cE.retry.retryableSet = true, true
+	}
+}
+
+// WithTemporary allows to specify whether the condition is temporary, or
+// permanent.
+func WithTemporary(temporary bool) Option {
+	return func(cE *CustomError) {
+		cE.retry.temporary, cE.retry.temporarySet = temporary, true
+	}
+}
+
+// WithStack captures the stack trace where the error is created, see
+// `StackTrace`. It's opt-in because it's expensive.
+func WithStack() Option {
+	return func(cE *CustomError) {
+		cE.stack = callers()
+	}
+}
+
+// WithSeverity allows to specify the severity of the error.
+func WithSeverity(severity Severity) Option {
+	return func(cE *CustomError) {
+		cE.Severity = severity
+		cE.severityDefault = false
+	}
+}
+
+// WithSensitiveField allows to set a field which value is sensitive, e.g.: a
+// token. It's masked unless the error is rendered internally, see `Redact`,
+// and `RenderInternal`.
+func WithSensitiveField(key string, value any) Option {
+	return WithField(key, Redact(value))
+}
+
+// WithRenderMode allows to specify how the error is rendered, see
+// `RenderMode`.
+func WithRenderMode(mode RenderMode) Option {
+	return func(cE *CustomError) {
+		cE.renderMode = mode
+	}
+}
+
+// WithContext allows to set fields extracted from `ctx` by the registered
+// extractors, e.g.: request ID, tenant, and user, so errors are correlated to
+// the request they were created in. Fields already set aren't overridden.
+//
+// SEE `RegisterContextExtractor`.
+func WithContext(ctx context.Context) Option {
+	return func(cE *CustomError) {
+		if ctx == nil {
+			return
 		}
 
-		cE.Fields.Store(key, value)
+		// Extractors are called unlocked, so they can register extractors, or
+		// create errors with a context.
+		contextExtractorsMu.RLock()
+		registered := contextExtractors
+		contextExtractorsMu.RUnlock()
+
+		for _, entry := range registered {
+			if _, ok := cE.Fields.Load(entry.key); ok {
+				continue
+			}
+
+			if value, ok := entry.extractor(ctx); ok {
+				cE.Fields = cE.Fields.With(entry.key, value)
+			}
+		}
+	}
+}
+
+// WithMergedChain makes JSON include the fields, and tags of every
+// `CustomError` in the chain, see `AllFields`, and `AllTags`.
+func WithMergedChain() Option {
+	return func(cE *CustomError) {
+		cE.mergeChain = true
 	}
 }
 
@@ -147,14 +236,14 @@ func WithLanguage(lang string) Option {
 			panic(err)
 		}
 
-		if cE.LanguageMessageMap == nil {
-			cE.LanguageMessageMap = &sync.Map{}
-		}
+		if msg, ok := cE.LanguageMessageMap[l]; ok {
+			if cE.untranslated == "" {
+				cE.untranslated = cE.Message
+			}
 
-		if msg, ok := cE.LanguageMessageMap.Load(l); ok {
 			cE.language = l
 
-			cE.SetMessage(msg.(string))
+			cE.SetMessage(msg)
 		}
 	}
 }
@@ -162,15 +251,125 @@ func WithLanguage(lang string) Option {
 // WithTranslation sets translations for the error message.
 func WithTranslation(lang, message string) Option {
 	return func(cE *CustomError) {
-		if cE.LanguageMessageMap == nil {
-			cE.LanguageMessageMap = &sync.Map{}
+		l, err := NewLanguage(lang)
+		if err != nil {
+			panic(err)
+		}
+
+		cE.LanguageMessageMap = cE.LanguageMessageMap.With(l, message)
+	}
+}
+
+// WithErrorTypeTemplate sets the template for `lang`, and `errorType` of the
+// error, e.g.: `WithErrorTypeTemplate("en", Invalid, "%s is invalid")`. It wins
+// over the global one, see `SetErrorPrefixMap`, and is inherited by factory
+// methods.
+func WithErrorTypeTemplate(lang string, errorType ErrorType, template string) Option {
+	return func(cE *CustomError) {
+		l, err := NewLanguage(lang)
+		if err != nil {
+			panic(err)
+		}
+
+		cE.LanguageErrorTypeMap = withTemplate(cE.LanguageErrorTypeMap, l, errorType, template)
+	}
+}
+
+// WithErrorType allows to specify the type of the error, e.g.: `NotFound`.
+func WithErrorType(errorType ErrorType) Option {
+	return func(cE *CustomError) {
+		cE.errorType = errorType
+	}
+}
+
+// WithDeprecation deprecates the error in favor of the `replacement` code, if
+// any, to be removed at `sunset`, if not zero. Deprecated errors trigger the
+// `OnDeprecated` hooks.
+func WithDeprecation(replacement string, sunset time.Time) Option {
+	return func(cE *CustomError) {
+		cE.deprecation = &Deprecation{Replacement: replacement, Sunset: sunset}
+	}
+}
+
+// WithFingerprintFields includes the values of the fields with `keys` in the
+// fingerprint, see `Fingerprint`.
+func WithFingerprintFields[K ~string](keys ...K) Option {
+	return func(cE *CustomError) {
+		for _, key := range keys {
+			cE.fingerprintFields = cE.fingerprintFields.With(string(key))
 		}
+	}
+}
+
+//////
+// Hook options.
+//////
+
+// WithHookCatalog scopes a hook to errors from the catalog `c` - errors got
+// from it, or created from its entries.
+func WithHookCatalog(c *Catalog) HookOption {
+	return func(h *hookEntry) {
+		h.catalog = c
+	}
+}
+
+// WithHookPriority allows to specify the priority of a hook. Hooks with lower
+// priority are called first. Default is `0`. Hooks with the same priority are
+// called in registration order.
+func WithHookPriority(priority int) HookOption {
+	return func(h *hookEntry) {
+		h.priority = priority
+	}
+}
+
+//////
+// Catalog options.
+//////
+
+// WithCodePolicy allows to specify how the catalog codes are validated, e.g.:
+// `StrictCodePolicy`. Default is `DefaultCodePolicy`.
+func WithCodePolicy(policy CodePolicy) CatalogOption {
+	return func(c *Catalog) {
+		c.codePolicy = policy
+	}
+}
+
+// WithCodePrefix prefixes the catalog codes with its name, upper cased, e.g.:
+// "ERR_A1_B2" in the "myapp" catalog is "MYAPP_ERR_A1_B2". It avoids
+// collisions between catalogs of different applications.
+func WithCodePrefix() CatalogOption {
+	return func(c *Catalog) {
+		c.prefix = codePrefixOf(c.Name)
+	}
+}
 
+// WithCatalogErrorTypeTemplate sets the template for `lang`, and `errorType`
+// of the catalog errors, see `WithErrorTypeTemplate`. Templates of the errors
+// win.
+func WithCatalogErrorTypeTemplate(lang string, errorType ErrorType, template string) CatalogOption {
+	return func(c *Catalog) {
 		l, err := NewLanguage(lang)
 		if err != nil {
 			panic(err)
 		}
 
-		cE.LanguageMessageMap.Store(l, message)
+		c.templates = withTemplate(c.templates, l, errorType, template)
+	}
+}
+
+//////
+// Mapper options.
+//////
+
+// WithBuiltInMappings adds the built-in rules:
+//   - `sql.ErrNoRows`: "record not found" (404)
+//   - `os.ErrNotExist`: "file not found" (404)
+//   - `io.ErrUnexpectedEOF`: "invalid input. Unexpected end of data" (400)
+//   - `*net.OpError`: "failed to reach the network" (503, retryable)
+//   - `context.DeadlineExceeded`: "failed to complete before the deadline"
+//     (504, retryable)
+func WithBuiltInMappings() MapperOption {
+	return func(m *Mapper) {
+		m.rules = append(m.rules, builtInMappings()...)
 	}
 }
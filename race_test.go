@@ -0,0 +1,5 @@
+//go:build race
+
+package customerror
+
+const raceEnabled = true
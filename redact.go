@@ -0,0 +1,132 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"encoding/json"
+	"regexp"
+	"sync"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// RedactedMask replaces sensitive values when rendered publicly.
+const RedactedMask = "[REDACTED]"
+
+var (
+	// DefaultSensitiveKeyPatterns are the key patterns considered sensitive
+	// out of the box.
+	DefaultSensitiveKeyPatterns = []string{
+		`(?i)api[_-]?key`,
+		`(?i)authorization`,
+		`(?i)passw(or)?d`,
+		`(?i)secret`,
+		`(?i)token`,
+	}
+
+	sensitiveKeysMu sync.RWMutex
+	sensitiveKeys   = mustCompileAll(DefaultSensitiveKeyPatterns...)
+)
+
+// Redacted wraps a sensitive value. It renders as `RedactedMask` everywhere -
+// `Error`, `APIError`, JSON, `fmt` - unless the error is rendered internally,
+// see `RenderInternal`.
+type Redacted struct {
+	// Value is the sensitive value.
+	Value any
+}
+
+//////
+// Helpers.
+//////
+
+// mustCompileAll compiles all `patterns`, panicking on error. Only used for
+// the built-in patterns.
+func mustCompileAll(patterns ...string) []*regexp.Regexp {
+	regexes := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, p := range patterns {
+		regexes = append(regexes, regexp.MustCompile(p))
+	}
+
+	return regexes
+}
+
+// unredact returns the underlying value of `v` if it's `Redacted`.
+func unredact(v any) any {
+	if r, ok := v.(Redacted); ok {
+		return r.Value
+	}
+
+	return v
+}
+
+//////
+// Methods.
+//////
+
+// String implements the Stringer interface.
+func (r Redacted) String() string {
+	return RedactedMask
+}
+
+// GoString implements the GoStringer interface, so `%#v` doesn't leak it.
+func (r Redacted) GoString() string {
+	return RedactedMask
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r Redacted) MarshalJSON() ([]byte, error) {
+	return json.Marshal(RedactedMask)
+}
+
+//////
+// Exported functionalities.
+//////
+
+// Redact marks `value` as sensitive.
+func Redact(value any) Redacted {
+	return Redacted{Value: value}
+}
+
+// IsSensitiveKey returns true if `key` matches any registered sensitive key
+// pattern.
+func IsSensitiveKey(key string) bool {
+	sensitiveKeysMu.RLock()
+	defer sensitiveKeysMu.RUnlock()
+
+	for _, re := range sensitiveKeys {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RegisterSensitiveKeys registers key patterns (regular expressions). Values
+// of fields which key matches any of them are treated as sensitive, even if
+// not set with `WithSensitiveField`, or `Redact`.
+func RegisterSensitiveKeys(patterns ...string) error {
+	regexes := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return NewInvalidError("sensitive key pattern", WithError(err), WithErrorCode("CE_ERR_INVALID_SENSITIVE_KEY_PATTERN"))
+		}
+
+		regexes = append(regexes, re)
+	}
+
+	sensitiveKeysMu.Lock()
+	defer sensitiveKeysMu.Unlock()
+
+	sensitiveKeys = append(sensitiveKeys, regexes...)
+
+	return nil
+}
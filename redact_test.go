@@ -0,0 +1,110 @@
+package customerror
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedaction(t *testing.T) {
+	// Restore the registry afterwards.
+	sensitiveKeysMu.RLock()
+	original := sensitiveKeys
+	sensitiveKeysMu.RUnlock()
+
+	t.Cleanup(func() {
+		sensitiveKeysMu.Lock()
+		sensitiveKeys = original
+		sensitiveKeysMu.Unlock()
+	})
+
+	assert.NoError(t, RegisterSensitiveKeys(`^email$`))
+	assert.Error(t, RegisterSensitiveKeys(`(`))
+
+	opts := []Option{
+		WithErrorCode(code),
+		WithStatusCode(http.StatusUnauthorized),
+		WithField("userID", 42),
+		WithSensitiveField("session", "s3ss10n"),
+		WithField("accessToken", "t0k3n"),
+		WithField("email", "john@doe.com"),
+		WithField("card", Redact("4111111111111111")),
+	}
+
+	tests := []struct {
+		name   string
+		render func(cE *CustomError) string
+		want   string
+	}{
+		{
+			name:   "Should mask - Error",
+			render: func(cE *CustomError) string { return cE.Error() },
+			want:   "E1010: user not allowed. Fields: userID=42, session=[REDACTED], accessToken=[REDACTED], email=[REDACTED], card=[REDACTED]",
+		},
+		{
+			name:   "Should mask - APIError",
+			render: func(cE *CustomError) string { return cE.APIError() },
+			want:   "E1010: user not allowed (401 - Unauthorized). Fields: userID=42, session=[REDACTED], accessToken=[REDACTED], email=[REDACTED], card=[REDACTED]",
+		},
+		{
+			name: "Should mask - MarshalJSON",
+			render: func(cE *CustomError) string {
+				b, err := json.Marshal(cE)
+				assert.NoError(t, err)
+
+				return string(b)
+			},
+			want: `{"accessToken":"[REDACTED]","card":"[REDACTED]","code":"E1010","email":"[REDACTED]","message":"user not allowed","session":"[REDACTED]","severity":"warning","userID":42}`,
+		},
+		{
+			name: "Should mask - fmt",
+			render: func(cE *CustomError) string {
+				return fmt.Sprintf("%v %+v %#v", Redact("secret"), Redact("secret"), Redact("secret"))
+			},
+			want: "[REDACTED] [REDACTED] [REDACTED]",
+		},
+		{
+			name:   "Should reveal - Error, internal",
+			render: func(cE *CustomError) string { return cE.Internal().Error() },
+			want:   "E1010: user not allowed. Fields: userID=42, session=s3ss10n, accessToken=t0k3n, email=john@doe.com, card=4111111111111111",
+		},
+		{
+			name:   "Should reveal - APIError, internal",
+			render: func(cE *CustomError) string { return cE.Internal().APIError() },
+			want:   "E1010: user not allowed (401 - Unauthorized). Fields: userID=42, session=s3ss10n, accessToken=t0k3n, email=john@doe.com, card=4111111111111111",
+		},
+		{
+			name: "Should reveal - MarshalJSON, internal",
+			render: func(cE *CustomError) string {
+				b, err := json.Marshal(New(cE.Message, append(opts, WithRenderMode(RenderInternal))...))
+				assert.NoError(t, err)
+
+				return string(b)
+			},
+			want: `{"accessToken":"t0k3n","card":"4111111111111111","code":"E1010","email":"john@doe.com","message":"user not allowed","session":"s3ss10n","severity":"warning","userID":42}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cE := New("user not allowed", opts...).(*CustomError)
+
+			assert.Equal(t, tt.want, tt.render(cE))
+
+			// Programmatic access isn't affected.
+			session, ok := GetField[string](cE, "session")
+			assert.True(t, ok)
+			assert.Equal(t, "s3ss10n", session)
+		})
+	}
+}
+
+func TestCustomError_RenderedFields(t *testing.T) {
+	cE := New("user not allowed", WithField("userID", 42), WithSensitiveField("session", "s3ss10n")).(*CustomError)
+
+	assert.Equal(t, map[string]any{"userID": 42, "session": Redact("s3ss10n")}, cE.RenderedFields().ToMap())
+	assert.Equal(t, "[REDACTED]", fmt.Sprint(cE.RenderedFields().ToMap()["session"]))
+	assert.Equal(t, map[string]any{"userID": 42, "session": "s3ss10n"}, cE.Internal().RenderedFields().ToMap())
+}
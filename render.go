@@ -0,0 +1,91 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+//////
+// Consts, vars, and types.
+//////
+
+// Render modes.
+const (
+	// RenderPublic is safe to be shown to clients, and to be logged. Sensitive
+	// values are masked, and JSON omits the wrapped error. It's the default.
+	RenderPublic RenderMode = iota
+
+	// RenderInternal renders everything as it is, including sensitive values,
+	// and the wrapped error ("cause" in JSON). Use it only where it's safe,
+	// e.g.: internal logs.
+	RenderInternal
+)
+
+// RenderMode determines how an error is rendered by `Error`, `APIError`, and
+// `MarshalJSON`.
+type RenderMode int
+
+//////
+// Helpers.
+//////
+
+// renderValue returns how a field value is rendered in the given mode.
+func renderValue(mode RenderMode, key string, value any) any {
+	if mode == RenderInternal {
+		return unredact(value)
+	}
+
+	if _, ok := value.(Redacted); ok {
+		return value
+	}
+
+	if IsSensitiveKey(key) {
+		return Redact(value)
+	}
+
+	return value
+}
+
+//////
+// Methods.
+//////
+
+// String implements the Stringer interface.
+func (m RenderMode) String() string {
+	if m == RenderInternal {
+		return "internal"
+	}
+
+	return "public"
+}
+
+// RenderedFields returns the fields as they are rendered, according to the
+// render mode of the error: sensitive values are masked unless it's rendered
+// internally. Use it to export fields outside of `Error`, `APIError`, and JSON,
+// e.g.: tracing, and reporting.
+func (cE *CustomError) RenderedFields() Fields {
+	list := make([]Field, 0, cE.Fields.Len())
+
+	cE.Fields.Range(func(key string, value any) bool {
+		list = append(list, Field{Key: key, Value: renderValue(cE.renderMode, key, value)})
+
+		return true
+	})
+
+	return Fields{list: list}
+}
+
+// Internal returns a copy of the error rendered internally, e.g.:
+// `log.Println(cE.Internal())`. Wrapped errors keep their own render mode.
+//
+// SEE `RenderInternal`.
+func (cE *CustomError) Internal() *CustomError {
+	if cE == nil {
+		return nil
+	}
+
+	internal := *cE
+
+	internal.renderMode = RenderInternal
+
+	return &internal
+}
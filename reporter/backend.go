@@ -0,0 +1,150 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+type (
+	// WriterBackend writes entries as newline-delimited JSON to a writer.
+	WriterBackend struct {
+		closer io.Closer
+		mu     sync.Mutex
+		w      io.Writer
+	}
+
+	// WebhookBackend POSTs entries as a JSON array to a webhook.
+	WebhookBackend struct {
+		// Client used to send requests. Default is `http.DefaultClient`.
+		Client *http.Client
+
+		// Header is added to every request, e.g.: authorization.
+		Header http.Header
+
+		// URL of the webhook.
+		URL string
+	}
+)
+
+//////
+// Methods.
+//////
+
+// Write implements the `Backend` interface. Each batch is written at once.
+func (wB *WriterBackend) Write(_ context.Context, entries []Entry) error {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("reporter: failed to encode report: %w", err)
+		}
+	}
+
+	wB.mu.Lock()
+	defer wB.mu.Unlock()
+
+	if _, err := wB.w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("reporter: failed to write reports: %w", err)
+	}
+
+	return nil
+}
+
+// Close implements the `io.Closer` interface. Only files opened by
+// `NewFileBackend` are closed.
+func (wB *WriterBackend) Close() error {
+	if wB.closer == nil {
+		return nil
+	}
+
+	return wB.closer.Close()
+}
+
+// Write implements the `Backend` interface. Any response status code other
+// than 2xx is an error.
+func (wB *WebhookBackend) Write(ctx context.Context, entries []Entry) error {
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("reporter: failed to encode reports: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wB.URL, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("reporter: failed to create webhook request: %w", err)
+	}
+
+	for key, values := range wB.Header {
+		req.Header[key] = values
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := wB.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("reporter: failed to deliver reports: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	// Allows the connection to be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("reporter: failed to deliver reports. Status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+//////
+// Factory.
+//////
+
+// NewWriterBackend creates a backend which writes newline-delimited JSON to
+// `w`. It doesn't close `w`.
+func NewWriterBackend(w io.Writer) *WriterBackend {
+	return &WriterBackend{w: w}
+}
+
+// NewStdoutBackend creates a backend which writes newline-delimited JSON to
+// stdout.
+func NewStdoutBackend() *WriterBackend {
+	return NewWriterBackend(os.Stdout)
+}
+
+// NewFileBackend creates a backend which appends newline-delimited JSON to the
+// file at `path`, creating it if needed. The file is closed by `Close`.
+func NewFileBackend(path string) (*WriterBackend, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("reporter: failed to open report file: %w", err)
+	}
+
+	return &WriterBackend{closer: f, w: f}, nil
+}
+
+// NewWebhookBackend creates a backend which POSTs entries to `url`.
+func NewWebhookBackend(url string) *WebhookBackend {
+	return &WebhookBackend{URL: url}
+}
@@ -0,0 +1,120 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalesfsp/customerror"
+)
+
+var testTime = time.Date(2023, 3, 29, 10, 0, 0, 0, time.UTC)
+
+func newTestError() *customerror.CustomError {
+	return customerror.Factory(
+		"user not allowed",
+		customerror.WithErrorCode("E1010"),
+		customerror.WithError(errors.New("db down")),
+		customerror.WithField("userID", 42),
+		customerror.WithSensitiveField("email", "john@doe.com"),
+		customerror.WithSeverity(customerror.SeverityCritical),
+		customerror.WithStatusCode(http.StatusForbidden),
+		customerror.WithTag("auth", "users"),
+	)
+}
+
+func TestNewFileBackend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.ndjson")
+
+	backend, err := NewFileBackend(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b := MustNew(backend)
+
+	b.now = func() time.Time { return testTime }
+
+	b.Report(newTestError())
+	b.Report(customerror.Factory("some error"))
+
+	assert.NoError(t, b.Close(context.Background()))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `{"cause":"db down","code":"E1010","fields":{"email":"[REDACTED]","userID":42},"fingerprint":"c1a925586393886592d9a78ee379b25b641d6957acc34290e4d527c0008fcedc","message":"user not allowed","severity":"critical","statusCode":403,"tags":["auth","users"],"time":"2023-03-29T10:00:00Z"}
+{"fingerprint":"d7f4f0d4e7f41653770a857b38cb37c3baabb6f2a6c054456a20407827a97c00","message":"some error","severity":"error","time":"2023-03-29T10:00:00Z"}
+`, string(content))
+}
+
+func TestNewFileBackend_invalidPath(t *testing.T) {
+	_, err := NewFileBackend(filepath.Join(t.TempDir(), "missing", "errors.ndjson"))
+
+	assert.ErrorContains(t, err, "failed to open report file")
+}
+
+func TestWebhookBackend(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "Should deliver",
+			statusCode: http.StatusAccepted,
+		},
+		{
+			name:       "Should fail - non 2xx",
+			statusCode: http.StatusServiceUnavailable,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Entry
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, "Bearer xyz", r.Header.Get("Authorization"))
+
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			backend := NewWebhookBackend(server.URL)
+
+			backend.Header = http.Header{"Authorization": []string{"Bearer xyz"}}
+
+			var handled []error
+
+			b := MustNew(backend, WithErrorHandler(func(err error) { handled = append(handled, err) }))
+
+			b.Report(newTestError())
+
+			assert.NoError(t, b.Close(context.Background()))
+
+			if assert.Len(t, got, 1) {
+				assert.Equal(t, "E1010", got[0].Code)
+				assert.Equal(t, customerror.SeverityCritical, got[0].Severity)
+				assert.Equal(t, "[REDACTED]", got[0].Fields["email"])
+			}
+
+			assert.Equal(t, tt.wantErr, len(handled) == 1)
+		})
+	}
+}
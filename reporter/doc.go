@@ -0,0 +1,27 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package reporter ships custom errors - with fields, tags, stack trace, and
+// severity - to pluggable backends: newline-delimited JSON files, stdout, and
+// HTTP webhooks.
+//
+// `Batcher` is an asynchronous `Reporter`: errors are queued, and written in
+// batches. The queue is bounded - when full, errors are dropped according to
+// the drop policy -, errors can be sampled by code to survive error storms,
+// and `Close` flushes pending errors on shutdown.
+//
+// Example:
+//
+//	backend, err := reporter.NewFileBackend("errors.ndjson")
+//	if err != nil {
+//		return err
+//	}
+//
+//	r := reporter.MustNew(backend, reporter.WithSampling("E1010", 10))
+//	defer r.Close(context.Background())
+//
+//	// Report every constructed custom error.
+//	unregister := r.Register()
+//	defer unregister()
+package reporter
@@ -0,0 +1,69 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package reporter
+
+import (
+	"time"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// Option allows to define batcher options.
+type Option func(b *Batcher)
+
+//////
+// Built-in options.
+//////
+
+// WithBatchSize allows to specify the maximum number of entries per batch.
+// It must be positive. Default is `DefaultBatchSize`.
+func WithBatchSize(size int) Option {
+	return func(b *Batcher) {
+		b.batchSize = size
+	}
+}
+
+// WithDropPolicy allows to specify what happens when the queue is full.
+// Default is `DropNewest`.
+func WithDropPolicy(policy DropPolicy) Option {
+	return func(b *Batcher) {
+		b.dropPolicy = policy
+	}
+}
+
+// WithErrorHandler allows to specify what to do when the backend fails to
+// write a batch. Default is logging it.
+func WithErrorHandler(handler func(err error)) Option {
+	return func(b *Batcher) {
+		b.errorHandler = handler
+	}
+}
+
+// WithFlushInterval allows to specify the maximum time an entry waits in the
+// queue, before being written. It must be positive. Default is
+// `DefaultFlushInterval`.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(b *Batcher) {
+		b.flushInterval = interval
+	}
+}
+
+// WithQueueSize allows to specify the maximum number of queued entries. It
+// can't be negative, nor zero with `DropOldest`. Default is `DefaultQueueSize`.
+func WithQueueSize(size int) Option {
+	return func(b *Batcher) {
+		b.queueSize = size
+	}
+}
+
+// WithSampling reports only 1 of every `every` errors with `code`. An empty
+// `code` applies to all codes without a specific sampling.
+func WithSampling(code string, every uint64) Option {
+	return func(b *Batcher) {
+		b.sampling[code] = every
+	}
+}
@@ -0,0 +1,430 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package reporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thalesfsp/customerror"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// Defaults.
+const (
+	// DefaultBatchSize is the default maximum number of entries per batch.
+	DefaultBatchSize = 100
+
+	// DefaultFlushInterval is the default maximum time an entry waits in the
+	// queue.
+	DefaultFlushInterval = 5 * time.Second
+
+	// DefaultQueueSize is the default maximum number of queued entries.
+	DefaultQueueSize = 1000
+)
+
+// Drop policies.
+const (
+	// Block blocks the caller until there's room in the queue.
+	Block DropPolicy = "block"
+
+	// DropNewest drops the error being reported.
+	DropNewest DropPolicy = "newest"
+
+	// DropOldest drops the oldest queued error, making room for the one being
+	// reported.
+	DropOldest DropPolicy = "oldest"
+)
+
+// Errors of invalid options, see `New`.
+var (
+	// ErrInvalidBatchSize is returned when the batch size isn't positive.
+	ErrInvalidBatchSize = errors.New("reporter: invalid batch size, it must be positive")
+
+	// ErrInvalidFlushInterval is returned when the flush interval isn't
+	// positive.
+	ErrInvalidFlushInterval = errors.New("reporter: invalid flush interval, it must be positive")
+
+	// ErrInvalidQueueSize is returned when the queue size is negative, or zero
+	// with `DropOldest` - there's nothing to drop.
+	ErrInvalidQueueSize = errors.New("reporter: invalid queue size, it can't be negative, nor zero dropping the oldest")
+)
+
+type (
+	// DropPolicy determines what happens when the queue is full.
+	DropPolicy string
+
+	// Reporter reports custom errors. Its `Report` method is a
+	// `customerror.Observer`.
+	Reporter interface {
+		Report(cE *customerror.CustomError)
+	}
+
+	// Func is a function which implements the `Reporter` interface.
+	Func func(cE *customerror.CustomError)
+
+	// Backend writes batches of entries somewhere. It must not retain
+	// `entries` after `Write` returns. If it implements `io.Closer`, it's
+	// closed by `Batcher.Close`.
+	//
+	// NOTE: Errors must be plain errors, not custom errors: custom errors
+	// notify observers - the batcher included -, which would report its own
+	// failures, over, and over again.
+	Backend interface {
+		Write(ctx context.Context, entries []Entry) error
+	}
+
+	// Entry is a reported custom error. Sensitive fields are masked, unless
+	// the error is rendered internally.
+	Entry struct {
+		// Cause is the message of the wrapped error, if any.
+		Cause string `json:"cause,omitempty"`
+
+		// Code of the error.
+		Code string `json:"code,omitempty"`
+
+		// Fields of the error.
+		Fields map[string]any `json:"fields,omitempty"`
+
+		// Fingerprint groups occurrences of the same error, see
+		// `customerror.CustomError.Fingerprint`.
+		Fingerprint string `json:"fingerprint"`
+
+		// Message of the error.
+		Message string `json:"message"`
+
+		// Severity of the error.
+		Severity customerror.Severity `json:"severity,omitempty"`
+
+		// Stack trace of the error, if captured.
+		Stack []customerror.Frame `json:"stack,omitempty"`
+
+		// StatusCode of the error.
+		StatusCode int `json:"statusCode,omitempty"`
+
+		// Tags of the error.
+		Tags []string `json:"tags,omitempty"`
+
+		// Time the error was reported.
+		Time time.Time `json:"time"`
+	}
+
+	// Batcher is an asynchronous `Reporter`, which writes errors in batches to
+	// a backend. It's safe for concurrent use.
+	Batcher struct {
+		backend       Backend
+		batchSize     int
+		cancel        context.CancelFunc
+		closeErr      error
+		closeOnce     sync.Once
+		closed        bool
+		ctx           context.Context
+		done          chan struct{}
+		dropPolicy    DropPolicy
+		dropped       atomic.Uint64
+		errorHandler  func(err error)
+		flushInterval time.Duration
+		mu            sync.RWMutex
+		now           func() time.Time
+		pending       sync.WaitGroup
+		queue         chan Entry
+		queueSize     int
+		sampling      map[string]uint64
+		samplingCount map[string]uint64
+		samplingMu    sync.Mutex
+		stop          chan struct{}
+	}
+)
+
+//////
+// Helpers.
+//////
+
+// sample returns true if an error with `code` should be reported.
+func (b *Batcher) sample(code string) bool {
+	every, ok := b.sampling[code]
+	if !ok {
+		every, ok = b.sampling[""]
+	}
+
+	if !ok || every <= 1 {
+		return true
+	}
+
+	b.samplingMu.Lock()
+	defer b.samplingMu.Unlock()
+
+	n := b.samplingCount[code]
+
+	b.samplingCount[code] = n + 1
+
+	return n%every == 0
+}
+
+// enqueue adds `entry` to the queue, according to the drop policy. Sends
+// never block while holding `mu`: `Close` would wait for them, and they may
+// wait for `Close` - e.g.: reporting from the consumer itself. Pending sends
+// are tracked instead, and give up once the batcher is closing.
+func (b *Batcher) enqueue(entry Entry) {
+	b.mu.RLock()
+
+	if b.closed {
+		b.mu.RUnlock()
+
+		b.dropped.Add(1)
+
+		return
+	}
+
+	b.pending.Add(1)
+	defer b.pending.Done()
+
+	b.mu.RUnlock()
+
+	switch b.dropPolicy {
+	case Block:
+		select {
+		case b.queue <- entry:
+		case <-b.stop:
+			b.dropped.Add(1)
+		}
+	case DropOldest:
+		for {
+			select {
+			case b.queue <- entry:
+				return
+			case <-b.stop:
+				b.dropped.Add(1)
+
+				return
+			default:
+			}
+
+			select {
+			case <-b.queue:
+				b.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case b.queue <- entry:
+		default:
+			b.dropped.Add(1)
+		}
+	}
+}
+
+// write writes `batch` to the backend.
+func (b *Batcher) write(batch []Entry) {
+	if len(batch) == 0 {
+		return
+	}
+
+	if err := b.backend.Write(b.ctx, batch); err != nil {
+		b.errorHandler(err)
+	}
+}
+
+// run consumes the queue until it's closed.
+func (b *Batcher) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Entry, 0, b.batchSize)
+
+	for {
+		select {
+		case entry, ok := <-b.queue:
+			if !ok {
+				b.write(batch)
+
+				return
+			}
+
+			batch = append(batch, entry)
+
+			if len(batch) >= b.batchSize {
+				b.write(batch)
+
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			b.write(batch)
+
+			batch = batch[:0]
+		}
+	}
+}
+
+//////
+// Methods.
+//////
+
+// Report implements the `Reporter` interface.
+func (f Func) Report(cE *customerror.CustomError) {
+	f(cE)
+}
+
+// Report queues `cE` to be written, see `WithSampling`, and `WithDropPolicy`.
+// It implements the `Reporter` interface.
+func (b *Batcher) Report(cE *customerror.CustomError) {
+	if cE == nil || !b.sample(cE.Code) {
+		return
+	}
+
+	entry := NewEntry(cE)
+
+	entry.Time = b.now()
+
+	b.enqueue(entry)
+}
+
+// Dropped returns the number of errors dropped because the queue was full, or
+// the batcher closed.
+func (b *Batcher) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Register registers the batcher as an observer, so every constructed custom
+// error is reported, see `customerror.RegisterObserver`. It returns a
+// function which unregisters it.
+func (b *Batcher) Register() (unregister func()) {
+	return customerror.RegisterObserver(b.Report)
+}
+
+// Close stops accepting errors, writes the queued ones, and closes the backend.
+// If `ctx` is done before, pending writes are canceled, and an error is
+// returned. It's safe to call it multiple times.
+func (b *Batcher) Close(ctx context.Context) error {
+	b.mu.Lock()
+
+	if !b.closed {
+		b.closed = true
+
+		close(b.stop)
+
+		b.mu.Unlock()
+
+		// No more sends: pending ones are done, and new ones are dropped.
+		b.pending.Wait()
+
+		close(b.queue)
+	} else {
+		b.mu.Unlock()
+	}
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		b.cancel()
+
+		return fmt.Errorf("reporter: failed to flush reports: %w", ctx.Err())
+	}
+
+	b.closeOnce.Do(func() {
+		b.cancel()
+
+		if closer, ok := b.backend.(io.Closer); ok {
+			b.closeErr = closer.Close()
+		}
+	})
+
+	return b.closeErr
+}
+
+//////
+// Factory.
+//////
+
+// NewEntry creates an entry from `cE`.
+func NewEntry(cE *customerror.CustomError) Entry {
+	entry := Entry{
+		Code:        cE.Code,
+		Fingerprint: cE.Fingerprint(),
+		Message:     cE.Message,
+		Severity:    cE.Severity,
+		Stack:       cE.StackTrace(),
+		StatusCode:  cE.StatusCode,
+	}
+
+	if cE.Err != nil {
+		entry.Cause = cE.Err.Error()
+	}
+
+	if fields := cE.RenderedFields(); fields.Len() > 0 {
+		entry.Fields = fields.ToMap()
+	}
+
+	if !cE.Tags.Empty() {
+		entry.Tags = cE.Tags.Values()
+	}
+
+	return entry
+}
+
+// New creates a new batcher writing to `backend`, and starts it. Call `Close`
+// to flush pending errors on shutdown.
+func New(backend Backend, opts ...Option) (*Batcher, error) {
+	b := &Batcher{
+		backend:       backend,
+		batchSize:     DefaultBatchSize,
+		done:          make(chan struct{}),
+		dropPolicy:    DropNewest,
+		errorHandler:  func(err error) { log.Println(err) },
+		flushInterval: DefaultFlushInterval,
+		now:           time.Now,
+		queueSize:     DefaultQueueSize,
+		sampling:      map[string]uint64{},
+		samplingCount: map[string]uint64{},
+		stop:          make(chan struct{}),
+	}
+
+	// Apply options.
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if b.batchSize <= 0 {
+		return nil, fmt.Errorf("%w. Batch size: %d", ErrInvalidBatchSize, b.batchSize)
+	}
+
+	if b.flushInterval <= 0 {
+		return nil, fmt.Errorf("%w. Flush interval: %s", ErrInvalidFlushInterval, b.flushInterval)
+	}
+
+	if b.queueSize < 0 || (b.queueSize == 0 && b.dropPolicy == DropOldest) {
+		return nil, fmt.Errorf("%w. Queue size: %d. Drop policy: %s", ErrInvalidQueueSize, b.queueSize, b.dropPolicy)
+	}
+
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+
+	b.queue = make(chan Entry, b.queueSize)
+
+	go b.run()
+
+	return b, nil
+}
+
+// MustNew is like `New`, but panics on error.
+func MustNew(backend Backend, opts ...Option) *Batcher {
+	b, err := New(backend, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
@@ -0,0 +1,302 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package reporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalesfsp/customerror"
+)
+
+// memoryBackend stores batches in memory. If `gate` is set, the first write
+// signals `writing`, and waits for `gate` to be closed.
+type memoryBackend struct {
+	batches [][]string
+	gate    chan struct{}
+	mu      sync.Mutex
+	once    sync.Once
+	writing chan struct{}
+}
+
+func (m *memoryBackend) Write(_ context.Context, entries []Entry) error {
+	if m.gate != nil {
+		m.once.Do(func() {
+			close(m.writing)
+
+			<-m.gate
+		})
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	batch := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		batch = append(batch, entry.Code)
+	}
+
+	m.batches = append(m.batches, batch)
+
+	return nil
+}
+
+func newGatedBackend() *memoryBackend {
+	return &memoryBackend{gate: make(chan struct{}), writing: make(chan struct{})}
+}
+
+func report(r Reporter, codes ...string) {
+	for _, code := range codes {
+		r.Report(customerror.Factory("some error", customerror.WithErrorCode(code)))
+	}
+}
+
+func TestBatcher(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		codes       []string
+		wantBatches [][]string
+	}{
+		{
+			name:        "Should write in batches, and flush on close",
+			opts:        []Option{WithBatchSize(2)},
+			codes:       []string{"E1", "E2", "E3", "E4", "E5"},
+			wantBatches: [][]string{{"E1", "E2"}, {"E3", "E4"}, {"E5"}},
+		},
+		{
+			name:        "Should sample by code",
+			opts:        []Option{WithBatchSize(10), WithSampling("E1", 2)},
+			codes:       []string{"E1", "E2", "E1", "E2", "E1"},
+			wantBatches: [][]string{{"E1", "E2", "E2", "E1"}},
+		},
+		{
+			name:        "Should sample all codes",
+			opts:        []Option{WithBatchSize(10), WithSampling("", 3), WithSampling("E2", 1)},
+			codes:       []string{"E1", "E1", "E1", "E1", "E2", "E2"},
+			wantBatches: [][]string{{"E1", "E1", "E2", "E2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &memoryBackend{}
+
+			b := MustNew(backend, tt.opts...)
+
+			report(b, tt.codes...)
+
+			assert.NoError(t, b.Close(context.Background()))
+
+			assert.Equal(t, tt.wantBatches, backend.batches)
+			assert.Zero(t, b.Dropped())
+		})
+	}
+}
+
+func TestBatcher_dropPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      DropPolicy
+		wantBatches [][]string
+		wantDropped uint64
+	}{
+		{
+			name:        "Should drop newest",
+			policy:      DropNewest,
+			wantBatches: [][]string{{"E1"}, {"E2"}, {"E3"}},
+			wantDropped: 2,
+		},
+		{
+			name:        "Should drop oldest",
+			policy:      DropOldest,
+			wantBatches: [][]string{{"E1"}, {"E4"}, {"E5"}},
+			wantDropped: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newGatedBackend()
+
+			b := MustNew(backend, WithBatchSize(1), WithQueueSize(2), WithDropPolicy(tt.policy))
+
+			report(b, "E1")
+
+			// Backend is busy, the queue fills.
+			<-backend.writing
+
+			report(b, "E2", "E3", "E4", "E5")
+
+			close(backend.gate)
+
+			assert.NoError(t, b.Close(context.Background()))
+
+			assert.Equal(t, tt.wantBatches, backend.batches)
+			assert.Equal(t, tt.wantDropped, b.Dropped())
+		})
+	}
+}
+
+func TestBatcher_Close(t *testing.T) {
+	backend := newGatedBackend()
+
+	b := MustNew(backend, WithBatchSize(1))
+
+	report(b, "E1")
+
+	<-backend.writing
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := b.Close(ctx)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	close(backend.gate)
+
+	assert.NoError(t, b.Close(context.Background()))
+
+	// Closed, dropped.
+	report(b, "E2")
+
+	assert.Equal(t, uint64(1), b.Dropped())
+}
+
+func TestBatcher_Register(t *testing.T) {
+	backend := &memoryBackend{}
+
+	b := MustNew(backend)
+
+	unregister := b.Register()
+
+	_ = customerror.NewInvalidError("id", customerror.WithErrorCode("E1"))
+
+	unregister()
+
+	_ = customerror.NewInvalidError("id", customerror.WithErrorCode("E2"))
+
+	assert.NoError(t, b.Close(context.Background()))
+
+	assert.Equal(t, [][]string{{"E1"}}, backend.batches)
+}
+
+func TestBatcher_Close_block(t *testing.T) {
+	backend := newGatedBackend()
+
+	b := MustNew(backend, WithBatchSize(1), WithQueueSize(1), WithDropPolicy(Block))
+
+	report(b, "E1")
+
+	<-backend.writing
+
+	report(b, "E2")
+
+	// Queue is full, it blocks until closed.
+	blocked := make(chan struct{})
+
+	go func() {
+		defer close(blocked)
+
+		report(b, "E3")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, b.Close(ctx), context.DeadlineExceeded)
+
+	<-blocked
+
+	close(backend.gate)
+
+	assert.NoError(t, b.Close(context.Background()))
+
+	assert.Equal(t, [][]string{{"E1"}, {"E2"}}, backend.batches)
+	assert.Equal(t, uint64(1), b.Dropped())
+}
+
+// failingBackend fails every write.
+type failingBackend struct {
+	writes atomic.Int64
+}
+
+func (f *failingBackend) Write(_ context.Context, _ []Entry) error {
+	f.writes.Add(1)
+
+	return errors.New("backend down")
+}
+
+func TestBatcher_failingBackend(t *testing.T) {
+	backend := &failingBackend{}
+
+	var handled []error
+
+	b := MustNew(backend, WithErrorHandler(func(err error) { handled = append(handled, err) }))
+
+	defer b.Register()()
+
+	_ = customerror.NewInvalidError("id", customerror.WithErrorCode("E1"))
+
+	assert.NoError(t, b.Close(context.Background()))
+
+	// Failures aren't reported.
+	assert.Equal(t, int64(1), backend.writes.Load())
+	assert.Len(t, handled, 1)
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{
+			name: "Should work",
+			opts: []Option{WithQueueSize(0)},
+		},
+		{
+			name:    "Should fail - batch size",
+			opts:    []Option{WithBatchSize(0)},
+			wantErr: ErrInvalidBatchSize,
+		},
+		{
+			name:    "Should fail - flush interval",
+			opts:    []Option{WithFlushInterval(0)},
+			wantErr: ErrInvalidFlushInterval,
+		},
+		{
+			name:    "Should fail - queue size",
+			opts:    []Option{WithQueueSize(-1)},
+			wantErr: ErrInvalidQueueSize,
+		},
+		{
+			name:    "Should fail - queue size, drop oldest",
+			opts:    []Option{WithQueueSize(0), WithDropPolicy(DropOldest)},
+			wantErr: ErrInvalidQueueSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := New(&memoryBackend{}, tt.opts...)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, b)
+
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.NoError(t, b.Close(context.Background()))
+			}
+		})
+	}
+}
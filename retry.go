@@ -0,0 +1,168 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// Retry is the retry metadata of an error. It tells clients whether, and when
+// to retry.
+type Retry struct {
+	// After is the suggested backoff before retrying. Zero means no
+	// suggestion.
+	After time.Duration `json:"after,omitempty"`
+
+	// Retryable is true if the operation can be retried.
+	Retryable bool `json:"retryable"`
+
+	// Temporary is true if the condition is temporary, as opposed to
+	// permanent.
+	Temporary bool `json:"temporary"`
+}
+
+// retryOverrides is the retry metadata set by options. What isn't set is
+// derived from the status code when read, so the order of the options doesn't
+// matter, e.g.: `WithRetryAfter`, then `WithStatusCode`.
+type retryOverrides struct {
+	after        time.Duration
+	retryable    bool
+	retryableSet bool
+	temporary    bool
+	temporarySet bool
+}
+
+//////
+// Helpers.
+//////
+
+// isRetryableStatusCode returns true if the status code is, by definition,
+// retryable.
+func isRetryableStatusCode(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// merge returns `r` with the overrides set in `other`. `other` wins.
+func (r retryOverrides) merge(other retryOverrides) retryOverrides {
+	if other.after != 0 {
+		r.after = other.after
+	}
+
+	if other.retryableSet {
+		r.retryable, r.retryableSet = other.retryable, true
+	}
+
+	if other.temporarySet {
+		r.temporary, r.temporarySet = other.temporary, true
+	}
+
+	return r
+}
+
+// retryOf returns the retry metadata of `cE`: `Retry`, if set, otherwise the
+// overrides, and what isn't overridden derived from the status code. It also
+// returns whether retrying was explicitly decided.
+func (cE *CustomError) retryOf() (Retry, bool) {
+	if cE.Retry != nil {
+		return *cE.Retry, true
+	}
+
+	derived := isRetryableStatusCode(cE.StatusCode)
+
+	r := Retry{After: cE.retry.after, Retryable: derived, Temporary: derived}
+
+	if cE.retry.retryableSet {
+		r.Retryable = cE.retry.retryable
+	}
+
+	if cE.retry.temporarySet {
+		r.Temporary = cE.retry.temporary
+	}
+
+	return r, cE.retry.retryableSet
+}
+
+//////
+// Methods.
+//////
+
+// IsRetryable returns true if the error is retryable. If not set, it's
+// derived from the status code: 429, and 503 are retryable.
+func (cE *CustomError) IsRetryable() bool {
+	r, _ := cE.retryOf()
+
+	return r.Retryable
+}
+
+// IsTemporary returns true if the condition is temporary. If not set, it's
+// derived from the status code: 429, and 503 are temporary.
+func (cE *CustomError) IsTemporary() bool {
+	r, _ := cE.retryOf()
+
+	return r.Temporary
+}
+
+// RetryAfter returns the suggested backoff before retrying. Zero means no
+// suggestion.
+func (cE *CustomError) RetryAfter() time.Duration {
+	r, _ := cE.retryOf()
+
+	return r.After
+}
+
+//////
+// Exported functionalities.
+//////
+
+// IsRetryable returns true if `err` is retryable. It walks the chain, the
+// outermost explicit decision (`WithRetryable`, `WithRetryAfter`) wins. Also
+// retryable: errors with retryable status code (429, 503), and `net.Error`s
+// which timed out, or are temporary.
+func IsRetryable(err error) bool {
+	retryable := false
+
+	walkAll(err, func(err error) bool {
+		switch x := err.(type) {
+		case *CustomError:
+			r, explicit := x.retryOf()
+
+			retryable = r.Retryable
+
+			if explicit {
+				return false
+			}
+		case net.Error:
+			// `Temporary` is deprecated in `net.Error`, but still implemented
+			// by many errors.
+			t, ok := x.(interface{ Temporary() bool })
+
+			retryable = x.Timeout() || (ok && t.Temporary())
+		}
+
+		return !retryable
+	})
+
+	return retryable
+}
+
+// RetryAfter returns the suggested backoff of the first error in the chain of
+// `err` which has one.
+func RetryAfter(err error) (time.Duration, bool) {
+	var after time.Duration
+
+	walk(err, func(cE *CustomError) bool {
+		after = cE.RetryAfter()
+
+		return after == 0
+	})
+
+	return after, after > 0
+}
@@ -0,0 +1,151 @@
+package customerror
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// timeoutError is a `net.Error` which timed out.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantRetryable bool
+		wantAfter     time.Duration
+	}{
+		{
+			name:          "Should work - 503 is retryable",
+			err:           NewHTTPError(http.StatusServiceUnavailable),
+			wantRetryable: true,
+		},
+		{
+			name:          "Should work - 429 is retryable",
+			err:           NewHTTPError(http.StatusTooManyRequests),
+			wantRetryable: true,
+		},
+		{
+			name:          "Should work - 500 isn't retryable",
+			err:           NewFailedToError("create host"),
+			wantRetryable: false,
+		},
+		{
+			name:          "Should work - explicit, overrides status code",
+			err:           NewHTTPError(http.StatusServiceUnavailable, WithRetryable(false)),
+			wantRetryable: false,
+		},
+		{
+			name:          "Should work - retry after",
+			err:           NewFailedToError("create host", WithRetryAfter(2*time.Second)),
+			wantRetryable: true,
+			wantAfter:     2 * time.Second,
+		},
+		{
+			name:          "Should work - wrapped net.Error",
+			err:           NewFailedToError("reach host", WithError(&net.OpError{Op: "dial", Err: timeoutError{}})),
+			wantRetryable: true,
+		},
+		{
+			name:          "Should work - outer explicit decision wins over net.Error",
+			err:           NewFailedToError("reach host", WithRetryable(false), WithError(timeoutError{})),
+			wantRetryable: false,
+		},
+		{
+			name:          "Should work - deep in the chain",
+			err:           fmt.Errorf("wrapped: %w", New("outer", WithError(multiError{errors.New("some error"), New("inner", WithRetryAfter(time.Minute))}))),
+			wantRetryable: true,
+			wantAfter:     time.Minute,
+		},
+		{
+			name:          "Should work - not a custom error",
+			err:           errors.New("some error"),
+			wantRetryable: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRetryable, IsRetryable(tt.err))
+
+			after, ok := RetryAfter(tt.err)
+			assert.Equal(t, tt.wantAfter, after)
+			assert.Equal(t, tt.wantAfter > 0, ok)
+		})
+	}
+}
+
+func TestCustomError_IsTemporary(t *testing.T) {
+	factory := Factory("reach host", WithStatusCode(http.StatusServiceUnavailable))
+
+	assert.True(t, factory.IsTemporary())
+	assert.True(t, factory.NewFailedToError(WithStatusCode(http.StatusServiceUnavailable)).(*CustomError).IsTemporary())
+	assert.False(t, factory.NewFailedToError(WithTemporary(false)).(*CustomError).IsTemporary())
+
+	// Factory must be untouched.
+	assert.Nil(t, factory.Retry)
+}
+
+func TestWithRetry_order(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []Option
+		wantRetryable bool
+		wantTemporary bool
+	}{
+		{
+			name:          "Should derive from the status code - set after",
+			opts:          []Option{WithRetryAfter(5 * time.Second), WithStatusCode(http.StatusServiceUnavailable)},
+			wantRetryable: true,
+			wantTemporary: true,
+		},
+		{
+			name:          "Should derive from the status code - set before",
+			opts:          []Option{WithStatusCode(http.StatusServiceUnavailable), WithRetryAfter(5 * time.Second)},
+			wantRetryable: true,
+			wantTemporary: true,
+		},
+		{
+			name:          "Should override the status code - set after",
+			opts:          []Option{WithTemporary(false), WithStatusCode(http.StatusServiceUnavailable)},
+			wantRetryable: true,
+			wantTemporary: false,
+		},
+		{
+			name:          "Should override the status code - not retryable",
+			opts:          []Option{WithTemporary(true), WithStatusCode(http.StatusInternalServerError)},
+			wantRetryable: false,
+			wantTemporary: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//nolint:errorlint,forcetypeassert
+			cE := New("xyz", tt.opts...).(*CustomError)
+
+			assert.Equal(t, tt.wantRetryable, cE.IsRetryable())
+			assert.Equal(t, tt.wantTemporary, cE.IsTemporary())
+		})
+	}
+
+	// Overrides are inherited, and merged.
+	factory := Factory("reach host", WithRetryAfter(time.Second))
+
+	//nolint:errorlint,forcetypeassert
+	cE := factory.NewFailedToError(WithTemporary(true), WithStatusCode(http.StatusBadGateway)).(*CustomError)
+
+	assert.True(t, cE.IsRetryable())
+	assert.True(t, cE.IsTemporary())
+	assert.Equal(t, time.Second, cE.RetryAfter())
+}
@@ -0,0 +1,258 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"encoding/json"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// Members of the JSON rendering, see `MarshalJSON`.
+const (
+	memberCause    = "cause"
+	memberCode     = "code"
+	memberKind     = "kind"
+	memberMessage  = "message"
+	memberSeverity = "severity"
+	memberTags     = "tags"
+)
+
+// Members of the problem details rendering, see `MarshalProblemJSON`.
+const (
+	memberDetail = "detail"
+	memberStatus = "status"
+	memberTitle  = "title"
+	memberType   = "type"
+)
+
+// JSONSchemaDialect is the JSON Schema dialect of `JSONSchema`, and
+// `ProblemJSONSchema`.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// member is a well-known member of a rendering, and its schema.
+type member struct {
+	name     string
+	required bool
+	schema   map[string]any
+}
+
+var (
+	// members of the JSON rendering. Fields are flattened alongside them, but
+	// can't override them.
+	members = []member{
+		{name: memberCause, schema: map[string]any{
+			"description": "Message of the wrapped error. Only rendered internally.",
+			"type":        "string",
+		}},
+		{name: memberCode, schema: map[string]any{
+			"description": "Code of the error.",
+			"type":        "string",
+		}},
+		{name: memberKind, schema: map[string]any{
+			"description": "Type of the error, e.g.: \"not found\", see \"Kind\".",
+			"examples":    []string{FailedTo.String(), Invalid.String(), Missing.String(), NotFound.String(), Required.String()},
+			"type":        "string",
+		}},
+		{name: memberMessage, required: true, schema: map[string]any{
+			"description": "Message of the error, translated if a language is set.",
+			"type":        "string",
+		}},
+		{name: memberSeverity, schema: map[string]any{
+			"description": "Severity of the error.",
+			"enum":        severityNames(),
+			"type":        "string",
+		}},
+		{name: memberTags, schema: map[string]any{
+			"description": "Tags of the error.",
+			"items":       map[string]any{"type": "string"},
+			"type":        "array",
+		}},
+	}
+
+	// problemMembers of the problem details rendering, besides the members of
+	// the JSON rendering - except the message, which is the detail.
+	//
+	// SEE https://www.rfc-editor.org/rfc/rfc9457
+	problemMembers = []member{
+		{name: memberDetail, required: true, schema: map[string]any{
+			"description": "Message of the error, translated if a language is set.",
+			"type":        "string",
+		}},
+		{name: memberStatus, schema: map[string]any{
+			"description": "Status code of the error.",
+			"maximum":     599,
+			"minimum":     100,
+			"type":        "integer",
+		}},
+		{name: memberTitle, schema: map[string]any{
+			"description": "Status text of the status code.",
+			"type":        "string",
+		}},
+		{name: memberType, required: true, schema: map[string]any{
+			"description": "Problem type.",
+			"format":      "uri-reference",
+			"type":        "string",
+		}},
+	}
+
+	// reservedMembers can't be overridden by fields in the JSON rendering.
+	reservedMembers = memberNames(members)
+
+	// reservedProblemMembers can't be overridden by fields in the problem
+	// details rendering. The message isn't rendered, so it's not reserved.
+	reservedProblemMembers = memberNames(wireMembersOf(members, memberMessage), problemMembers)
+)
+
+//////
+// Helpers.
+//////
+
+// severityNames returns the names of all severities, in ascending order.
+func severityNames() []string {
+	names := []string{}
+
+	for s := SeverityDebug; s <= SeverityCritical; s++ {
+		names = append(names, s.String())
+	}
+
+	return names
+}
+
+// wireMembersOf returns `memberList`, except the `excluded` member.
+func wireMembersOf(memberList []member, excluded string) []member {
+	final := []member{}
+
+	for _, m := range memberList {
+		if m.name != excluded {
+			final = append(final, m)
+		}
+	}
+
+	return final
+}
+
+// memberNames returns the names of the members in `memberLists`.
+func memberNames(memberLists ...[]member) map[string]struct{} {
+	set := map[string]struct{}{}
+
+	for _, list := range memberLists {
+		for _, m := range list {
+			set[m.name] = struct{}{}
+		}
+	}
+
+	return set
+}
+
+// schemaOf returns the JSON Schema of an object with `memberLists`, and any
+// other member - the flattened fields.
+func schemaOf(title, description string, memberLists ...[]member) json.RawMessage {
+	properties := map[string]any{}
+	required := []string{}
+
+	for _, list := range memberLists {
+		for _, m := range list {
+			properties[m.name] = m.schema
+
+			if m.required {
+				required = append(required, m.name)
+			}
+		}
+	}
+
+	b, err := json.Marshal(map[string]any{
+		"$schema":              JSONSchemaDialect,
+		"additionalProperties": true,
+		"description":          description,
+		"properties":           properties,
+		"required":             required,
+		"title":                title,
+		"type":                 "object",
+	})
+	if err != nil {
+		// Should never happen, it's a static schema.
+		panic(err)
+	}
+
+	return b
+}
+
+// wireFormat returns the members of the JSON rendering, and the fields, except
+// `reserved` ones. The message is only rendered if reserved.
+func (cE *CustomError) wireFormat(reserved map[string]struct{}) map[string]interface{} {
+	// Define a temporary map that matches the desired JSON format.
+	temp := make(map[string]interface{})
+
+	// Populate the temporary map.
+	if _, ok := reserved[memberMessage]; ok {
+		temp[memberMessage] = cE.Message
+	}
+
+	if cE.Code != "" {
+		temp[memberCode] = cE.Code
+	}
+
+	if cE.errorType != "" {
+		temp[memberKind] = cE.errorType.String()
+	}
+
+	if cE.renderMode == RenderInternal && cE.Err != nil {
+		temp[memberCause] = cE.Err.Error()
+	}
+
+	if cE.Severity != 0 {
+		temp[memberSeverity] = cE.Severity.String()
+	}
+
+	fields, tags := cE.Fields, cE.Tags
+
+	if cE.mergeChain {
+		fields, tags = AllFields(cE), AllTags(cE)
+	}
+
+	if !tags.Empty() {
+		temp[memberTags] = tags
+	}
+
+	// Populate the fields of the temporary map. Well-known members win.
+	fields.Range(func(k string, v any) bool {
+		if _, ok := reserved[k]; k != "" && v != nil && !ok {
+			temp[k] = renderValue(cE.renderMode, k, v)
+		}
+
+		return true
+	})
+
+	return temp
+}
+
+//////
+// Exported functionalities.
+//////
+
+// JSONSchema returns the JSON Schema of the JSON rendering of custom errors,
+// see `MarshalJSON`. Fields are flattened, so any other member is allowed.
+func JSONSchema() json.RawMessage {
+	return schemaOf(
+		"CustomError",
+		"Custom error. Any other member is a field of the error.",
+		members,
+	)
+}
+
+// ProblemJSONSchema returns the JSON Schema of the problem details rendering
+// of custom errors, see `MarshalProblemJSON`. Fields are flattened, so any
+// other member is allowed.
+func ProblemJSONSchema() json.RawMessage {
+	return schemaOf(
+		"CustomErrorProblem",
+		"Custom error as problem details (RFC 9457). Any other member is a field of the error.",
+		wireMembersOf(members, memberMessage),
+		problemMembers,
+	)
+}
@@ -0,0 +1,216 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// compileSchema compiles `schema`, checking it against the meta-schema of its
+// dialect.
+func compileSchema(t *testing.T, schema json.RawMessage) *jsonschema.Schema {
+	t.Helper()
+
+	var doc any
+
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	// The schema is a valid schema of its dialect.
+	meta, err := jsonschema.CompileString(JSONSchemaDialect, `{"$ref": "`+JSONSchemaDialect+`"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := meta.Validate(doc); err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+
+	compiler.Draft = jsonschema.Draft2020
+
+	if err := compiler.AddResource("schema.json", bytes.NewReader(schema)); err != nil {
+		t.Fatal(err)
+	}
+
+	compiled, err := compiler.Compile("schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return compiled
+}
+
+// validateJSON validates `data` against `schema`.
+func validateJSON(t *testing.T, schema json.RawMessage, data []byte) error {
+	t.Helper()
+
+	var value any
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatal(err)
+	}
+
+	return compileSchema(t, schema).Validate(value)
+}
+
+func TestJSONSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{
+			name: "Should conform - new",
+			err:  New("some error"),
+		},
+		{
+			name: "Should conform - built-in",
+			err:  NewInvalidError("id", WithErrorCode("E1010"), WithTag("users", "auth")),
+		},
+		{
+			name: "Should conform - fields",
+			err: NewNotFoundError(
+				"user",
+				WithField("id", 42),
+				WithField("nested", map[string]any{"a": []int{1}}),
+				WithSensitiveField("email", "john@doe.com"),
+			),
+		},
+		{
+			name: "Should conform - fields overriding members",
+			err: NewFailedToError(
+				"create user",
+				WithField("code", 42),
+				WithField("kind", 42),
+				WithField("message", 1),
+				WithField("severity", "fatal"),
+				WithField("tags", "users"),
+				WithField("status", "x"),
+				WithField("type", 1),
+			),
+		},
+		{
+			name: "Should conform - internal, with cause",
+			err:  NewFailedToError("create user", WithError(errors.New("db down")), WithRenderMode(RenderInternal)),
+		},
+		{
+			name: "Should conform - merged chain",
+			err:  New("outer", WithMergedChain(), WithTag("a"), WithError(New("inner", WithField("id", 1), WithTag("b")))),
+			want: []string{`"id":1`, `"tags":["a","b"]`},
+		},
+		{
+			name: "Should conform - translated",
+			err:  NewMissingError("id", WithLanguage("pt-BR")),
+		},
+		{
+			name: "Should conform - http",
+			err:  NewHTTPError(http.StatusTooManyRequests, WithRetryAfter(1)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cE *CustomError
+
+			if !errors.As(tt.err, &cE) {
+				t.Fatal("not a custom error")
+			}
+
+			b, err := json.Marshal(cE)
+			assert.NoError(t, err)
+			assert.NoError(t, validateJSON(t, JSONSchema(), b), string(b))
+
+			problemB, err := cE.MarshalProblemJSON()
+			assert.NoError(t, err)
+			assert.NoError(t, validateJSON(t, ProblemJSONSchema(), problemB), string(problemB))
+
+			for _, want := range tt.want {
+				assert.Contains(t, string(b), want)
+				assert.Contains(t, string(problemB), want)
+			}
+		})
+	}
+}
+
+func TestJSONSchema_invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema json.RawMessage
+		data   string
+	}{
+		{
+			name:   "Should fail - missing message",
+			schema: JSONSchema(),
+			data:   `{"code":"E1010"}`,
+		},
+		{
+			name:   "Should fail - code type",
+			schema: JSONSchema(),
+			data:   `{"message":"x","code":42}`,
+		},
+		{
+			name:   "Should fail - unknown severity",
+			schema: JSONSchema(),
+			data:   `{"message":"x","severity":"fatal"}`,
+		},
+		{
+			name:   "Should fail - tags type",
+			schema: JSONSchema(),
+			data:   `{"message":"x","tags":[1]}`,
+		},
+		{
+			name:   "Should fail - missing type",
+			schema: ProblemJSONSchema(),
+			data:   `{"detail":"x"}`,
+		},
+		{
+			name:   "Should fail - status range",
+			schema: ProblemJSONSchema(),
+			data:   `{"detail":"x","type":"about:blank","status":42}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, validateJSON(t, tt.schema, []byte(tt.data)))
+		})
+	}
+}
+
+func TestCustomError_MarshalProblemJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "Should work",
+			err:  NewNotFoundError("user", WithErrorCode("E1010"), WithField("id", 42), WithField("type", "admin")),
+			want: `{"code":"E1010","detail":"user not found","id":42,"kind":"not found","severity":"warning","status":404,"title":"Not Found","type":"about:blank"}`,
+		},
+		{
+			name: "Should work - no status code",
+			err:  New("some error"),
+			want: `{"detail":"some error","severity":"error","type":"about:blank"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//nolint:errorlint
+			b, err := tt.err.(*CustomError).MarshalProblemJSON()
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(b))
+		})
+	}
+}
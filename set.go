@@ -0,0 +1,133 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// Set is a sorted SET of strings. The zero value is an empty set, ready to use.
+//
+// Set is copy-on-write: no method mutates the underlying storage, so it's
+// safely shared between a factory error and every error created from it.
+type Set struct {
+	items []string
+}
+
+//////
+// Methods.
+//////
+
+// Contains returns true if `item` is in the set.
+func (s Set) Contains(item string) bool {
+	i := sort.SearchStrings(s.items, item)
+
+	return i < len(s.items) && s.items[i] == item
+}
+
+// Each calls `fn` for each item, in ascending order.
+func (s Set) Each(fn func(index int, value string)) {
+	for i, item := range s.items {
+		fn(i, item)
+	}
+}
+
+// Empty returns true if the set has no items.
+func (s Set) Empty() bool {
+	return len(s.items) == 0
+}
+
+// Size returns the number of items.
+func (s Set) Size() int {
+	return len(s.items)
+}
+
+// Values returns a copy of the items, in ascending order.
+func (s Set) Values() []string {
+	values := make([]string, len(s.items))
+
+	copy(values, s.items)
+
+	return values
+}
+
+// With returns a copy of the set with `items` added. If all `items` are
+// already in the set, the set is returned as it is - no allocation.
+func (s Set) With(items ...string) Set {
+	missing := 0
+
+	for _, item := range items {
+		if !s.Contains(item) {
+			missing++
+		}
+	}
+
+	if missing == 0 {
+		return s
+	}
+
+	final := make([]string, len(s.items), len(s.items)+missing)
+
+	copy(final, s.items)
+
+	for _, item := range items {
+		i := sort.SearchStrings(final, item)
+
+		if i < len(final) && final[i] == item {
+			continue
+		}
+
+		final = append(final, "")
+
+		copy(final[i+1:], final[i:])
+
+		final[i] = item
+	}
+
+	return Set{items: final}
+}
+
+// Merge returns the union of `s` and `other`. If one of them is empty, the
+// other is returned as it is - no allocation.
+func (s Set) Merge(other Set) Set {
+	if len(other.items) == 0 {
+		return s
+	}
+
+	if len(s.items) == 0 {
+		return other
+	}
+
+	return s.With(other.items...)
+}
+
+// String implements the Stringer interface.
+func (s Set) String() string {
+	return strings.Join(s.items, ", ")
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s Set) MarshalJSON() ([]byte, error) {
+	if s.items == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(s.items)
+}
+
+//////
+// Factory.
+//////
+
+// NewSet creates a new set with the given items.
+func NewSet(items ...string) Set {
+	return Set{}.With(items...)
+}
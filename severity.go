@@ -0,0 +1,132 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"net/http"
+	"strings"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// Severity levels, in ascending order. The zero value means not set.
+const (
+	SeverityDebug Severity = iota + 1
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+// ErrInvalidSeverity is returned when a severity is invalid.
+var ErrInvalidSeverity = NewInvalidError("severity. It must be one of: debug, info, warning, error, critical", WithErrorCode("CE_ERR_INVALID_SEVERITY"))
+
+// Severity of an error. It allows logging, and alerting to treat errors
+// differently, e.g.: a missing optional header vs a failed payment.
+type Severity int
+
+//////
+// Helpers.
+//////
+
+// severityFromStatusCode derives the severity from the status code: server
+// errors are errors, anything else is a warning.
+func severityFromStatusCode(statusCode int) Severity {
+	if statusCode >= http.StatusContinue && statusCode < http.StatusInternalServerError {
+		return SeverityWarning
+	}
+
+	return SeverityError
+}
+
+// defaultSeverity returns the severity of `cE` if not set: built-in error
+// types have sensible defaults (validation: warning, failed to: error),
+// otherwise it's derived from the status code.
+func (cE *CustomError) defaultSeverity() Severity {
+	switch cE.errorType {
+	case FailedTo:
+		return SeverityError
+	case Invalid, Missing, NotFound, Required:
+		return SeverityWarning
+	}
+
+	return severityFromStatusCode(cE.StatusCode)
+}
+
+//////
+// Methods.
+//////
+
+// String implements the Stringer interface.
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "debug"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return ""
+	}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = severity
+
+	return nil
+}
+
+//////
+// Exported functionalities.
+//////
+
+// MaxSeverity returns the highest severity in the chain of `err`. It returns
+// zero if there's no `CustomError` in the chain.
+func MaxSeverity(err error) Severity {
+	var severity Severity
+
+	walk(err, func(cE *CustomError) bool {
+		if cE.Severity > severity {
+			severity = cE.Severity
+		}
+
+		return true
+	})
+
+	return severity
+}
+
+//////
+// Factory.
+//////
+
+// ParseSeverity parses a severity from its name, case insensitive.
+func ParseSeverity(name string) (Severity, error) {
+	for s := SeverityDebug; s <= SeverityCritical; s++ {
+		if strings.EqualFold(name, s.String()) {
+			return s, nil
+		}
+	}
+
+	return 0, ErrInvalidSeverity
+}
@@ -0,0 +1,101 @@
+package customerror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeverity_defaults(t *testing.T) {
+	catalog := MustNewCatalog("myapp").
+		MustSet("E1010", "user not found", WithStatusCode(http.StatusNotFound), WithSeverity(SeverityInfo)).
+		MustSet("E2020", "user not found", WithStatusCode(http.StatusNotFound))
+
+	tests := []struct {
+		name string
+		err  error
+		want Severity
+	}{
+		{name: "failed to", err: NewFailedToError("charge card"), want: SeverityError},
+		{name: "invalid", err: NewInvalidError("port"), want: SeverityWarning},
+		{name: "missing", err: NewMissingError("header"), want: SeverityWarning},
+		{name: "required", err: NewRequiredError("port is"), want: SeverityWarning},
+		{name: "not found", err: NewNotFoundError("host"), want: SeverityWarning},
+		{name: "http 4xx", err: NewHTTPError(http.StatusConflict), want: SeverityWarning},
+		{name: "http 5xx", err: NewHTTPError(http.StatusBadGateway), want: SeverityError},
+		{name: "no status code", err: New("some error"), want: SeverityError},
+		{name: "explicit", err: NewMissingError("header", WithSeverity(SeverityDebug)), want: SeverityDebug},
+		{name: "factory", err: Factory("charge card").NewFailedToError(), want: SeverityError},
+		{name: "factory, explicit", err: Factory("charge card").NewFailedToError(WithSeverity(SeverityCritical)), want: SeverityCritical},
+		{name: "factory, new", err: Factory("some error", WithSeverity(SeverityDebug)).New(), want: SeverityDebug},
+		{name: "factory, new, status code", err: Factory("some error", WithStatusCode(http.StatusNotFound)).New(), want: SeverityWarning},
+		{name: "factory, new, option status code", err: Factory("some error").New(WithStatusCode(http.StatusNotFound)), want: SeverityWarning},
+		{name: "factory, built-in", err: Factory("port", WithSeverity(SeverityCritical)).NewInvalidError(), want: SeverityCritical},
+		{name: "factory, built-in, status code", err: Factory("port", WithStatusCode(http.StatusInternalServerError)).NewInvalidError(), want: SeverityWarning},
+		{name: "factory, http", err: Factory("some error", WithSeverity(SeverityInfo)).NewHTTPError(http.StatusBadGateway), want: SeverityInfo},
+		{name: "catalog, new", err: catalog.MustGet("E1010").New(), want: SeverityInfo},
+		{name: "catalog, built-in", err: catalog.MustGet("E1010").NewMissingError(), want: SeverityInfo},
+		{name: "catalog, new, status code", err: catalog.MustGet("E2020").New(), want: SeverityWarning},
+		{name: "catalog, new, explicit", err: catalog.MustGet("E1010").New(WithSeverity(SeverityCritical)), want: SeverityCritical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.err.(*CustomError).Severity)
+		})
+	}
+}
+
+func TestMaxSeverity(t *testing.T) {
+	inner := NewFailedToError("charge card", WithSeverity(SeverityCritical))
+	outer := NewMissingError("header", WithError(multiError{errors.New("some error"), inner}))
+
+	assert.Equal(t, SeverityCritical, MaxSeverity(fmt.Errorf("wrapped: %w", outer)))
+	assert.Equal(t, SeverityWarning, MaxSeverity(NewMissingError("header")))
+	assert.Equal(t, Severity(0), MaxSeverity(errors.New("some error")))
+}
+
+func TestSeverity_json(t *testing.T) {
+	catalog := MustNewCatalog("myapp").
+		MustSet("E1010", "user not found", WithStatusCode(http.StatusNotFound), WithSeverity(SeverityInfo))
+
+	b, err := json.Marshal(catalog.MustGet("E1010").New())
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"severity":"info"`)
+}
+
+func TestSeverity_output(t *testing.T) {
+	err := NewMissingError("header", WithErrorCode("E1010"))
+
+	assert.Equal(t, "E1010: missing header", fmt.Sprintf("%v", err))
+	assert.Equal(t, "E1010: missing header", fmt.Sprintf("%s", err))
+	assert.Equal(t, `"E1010: missing header"`, fmt.Sprintf("%q", err))
+	assert.Equal(t, "E1010: missing header. Severity: warning", fmt.Sprintf("%+v", err))
+
+	b, jErr := json.Marshal(err)
+	assert.NoError(t, jErr)
+	assert.Equal(t, `{"code":"E1010","kind":"missing","message":"missing header","severity":"warning"}`, string(b))
+}
+
+func TestParseSeverity(t *testing.T) {
+	for s := SeverityDebug; s <= SeverityCritical; s++ {
+		got, err := ParseSeverity(s.String())
+		assert.NoError(t, err)
+		assert.Equal(t, s, got)
+	}
+
+	got, err := ParseSeverity("CRITICAL")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityCritical, got)
+
+	_, err = ParseSeverity("fatal")
+	assert.ErrorIs(t, err, ErrInvalidSeverity)
+
+	var s Severity
+	assert.NoError(t, json.Unmarshal([]byte(`"info"`), &s))
+	assert.Equal(t, SeverityInfo, s)
+}
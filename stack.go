@@ -0,0 +1,99 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package customerror
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// maxStackDepth is the maximum number of frames captured.
+const maxStackDepth = 32
+
+// pkgPrefix is used to skip the frames of this package, e.g.:
+// "github.com/thalesfsp/customerror.".
+var pkgPrefix = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+
+	name := runtime.FuncForPC(pc).Name()
+
+	lastSlash := strings.LastIndex(name, "/")
+
+	return name[:lastSlash+strings.Index(name[lastSlash:], ".")+1]
+}()
+
+// Frame is a stack frame.
+type Frame struct {
+	// File is the file path.
+	File string `json:"file"`
+
+	// Function is the fully qualified function name.
+	Function string `json:"function"`
+
+	// Line is the line number.
+	Line int `json:"line"`
+}
+
+//////
+// Helpers.
+//////
+
+// callers returns the program counters of the caller of the first function
+// outside of this package.
+func callers() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+
+	n := runtime.Callers(3, pcs)
+
+	return pcs[:n]
+}
+
+//////
+// Methods.
+//////
+
+// String implements the Stringer interface.
+func (f Frame) String() string {
+	return fmt.Sprintf("%s\n\t%s:%d", f.Function, f.File, f.Line)
+}
+
+// StackTrace returns the stack trace captured when the error was created, if
+// any, see `WithStack`. Frames of this package are skipped.
+func (cE *CustomError) StackTrace() []Frame {
+	if len(cE.stack) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(cE.stack)
+
+	stackTrace := make([]Frame, 0, len(cE.stack))
+
+	for {
+		frame, more := frames.Next()
+
+		// Skip this package's frames, but not its tests.
+		isInternal := strings.HasPrefix(frame.Function, pkgPrefix) &&
+			!strings.HasSuffix(frame.File, "_test.go")
+
+		if !isInternal || len(stackTrace) > 0 {
+			stackTrace = append(stackTrace, Frame{
+				File:     frame.File,
+				Function: frame.Function,
+				Line:     frame.Line,
+			})
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return stackTrace
+}
@@ -0,0 +1,35 @@
+package customerror
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithStack(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "New", err: New("some error", WithStack())},
+		{name: "builtin", err: NewMissingError("id", WithStack())},
+		{name: "factory", err: Factory("id").NewInvalidError(WithStack())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stackTrace := tt.err.(*CustomError).StackTrace()
+
+			assert.NotEmpty(t, stackTrace)
+			assert.Equal(t, "github.com/thalesfsp/customerror.TestWithStack", stackTrace[0].Function)
+			assert.True(t, strings.HasSuffix(stackTrace[0].File, "stack_test.go"))
+
+			formatted := fmt.Sprintf("%+v", tt.err)
+			assert.Contains(t, formatted, "Severity:")
+			assert.Contains(t, formatted, "\ngithub.com/thalesfsp/customerror.TestWithStack\n\t")
+		})
+	}
+
+	assert.Nil(t, New("some error").(*CustomError).StackTrace())
+}
@@ -0,0 +1,15 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Package tracing records custom errors on OpenTelemetry spans: span status
+// from `StatusCode`, and an exception event with `Code`, `Severity`, `Tags`,
+// `Fields` (sensitive values masked), and the stack trace - if captured - as
+// attributes. It also correlates errors to traces, injecting trace, and span
+// IDs into the error fields.
+//
+// It's a separate module, so the core package doesn't depend on
+// OpenTelemetry. It's built against the core module of the checkout, see
+// go.mod. To work on both at once, create a workspace in the repository root
+// (`go work init . ./tracing`), it's ignored by git.
+package tracing
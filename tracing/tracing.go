@@ -0,0 +1,188 @@
+// Copyright 2021 The customerror Authors. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/thalesfsp/customerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//////
+// Consts, vars, and types.
+//////
+
+// Field keys set by `WithTraceIDs`.
+const (
+	// FieldSpanID is the span ID.
+	FieldSpanID customerror.FieldKey[string] = "spanID"
+
+	// FieldTraceID is the trace ID.
+	FieldTraceID customerror.FieldKey[string] = "traceID"
+)
+
+// Attribute keys.
+const (
+	AttributeCode       = attribute.Key("customerror.code")
+	AttributeSeverity   = attribute.Key("customerror.severity")
+	AttributeStatusCode = attribute.Key("customerror.status_code")
+	AttributeTags       = attribute.Key("customerror.tags")
+
+	// AttributeFieldPrefix prefixes each field key.
+	AttributeFieldPrefix = "customerror.field."
+
+	// AttributeExceptionStacktrace follows the OpenTelemetry semantic
+	// conventions.
+	AttributeExceptionStacktrace = attribute.Key("exception.stacktrace")
+)
+
+//////
+// Helpers.
+//////
+
+// toAttribute converts a field to an attribute. Types not supported by
+// attributes are formatted, which also masks sensitive values.
+func toAttribute(key string, value any) attribute.KeyValue {
+	k := attribute.Key(AttributeFieldPrefix + key)
+
+	switch v := value.(type) {
+	case string:
+		return k.String(v)
+	case bool:
+		return k.Bool(v)
+	case int:
+		return k.Int(v)
+	case int64:
+		return k.Int64(v)
+	case float64:
+		return k.Float64(v)
+	case []string:
+		return k.StringSlice(v)
+	default:
+		return k.String(fmt.Sprint(v))
+	}
+}
+
+// isError returns true if the status code should set the span status to
+// error. It follows the OpenTelemetry HTTP server conventions: only server
+// errors (5xx) are span errors. No status code means an error.
+func isError(statusCode int) bool {
+	return statusCode == 0 || statusCode >= http.StatusInternalServerError
+}
+
+//////
+// Exported functionalities.
+//////
+
+// Attributes returns the attributes describing `cE`. Fields are rendered
+// according to the render mode of the error - sensitive values are masked
+// by default.
+func Attributes(cE *customerror.CustomError) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 5+cE.Fields.Len())
+
+	if cE.Code != "" {
+		attrs = append(attrs, AttributeCode.String(cE.Code))
+	}
+
+	if cE.StatusCode != 0 {
+		attrs = append(attrs, AttributeStatusCode.Int(cE.StatusCode))
+	}
+
+	if cE.Severity != 0 {
+		attrs = append(attrs, AttributeSeverity.String(cE.Severity.String()))
+	}
+
+	if !cE.Tags.Empty() {
+		attrs = append(attrs, AttributeTags.StringSlice(cE.Tags.Values()))
+	}
+
+	cE.RenderedFields().Range(func(key string, value any) bool {
+		attrs = append(attrs, toAttribute(key, value))
+
+		return true
+	})
+
+	if stackTrace := cE.StackTrace(); len(stackTrace) > 0 {
+		frames := make([]string, 0, len(stackTrace))
+
+		for _, frame := range stackTrace {
+			frames = append(frames, frame.String())
+		}
+
+		attrs = append(attrs, AttributeExceptionStacktrace.String(strings.Join(frames, "\n")))
+	}
+
+	return attrs
+}
+
+// RecordError records `err` on the span of `ctx`, see `RecordErrorOnSpan`.
+func RecordError(ctx context.Context, err error) {
+	RecordErrorOnSpan(trace.SpanFromContext(ctx), err)
+}
+
+// RecordErrorOnSpan records `err` on `span`: adds an exception event, and
+// sets the span status to error if the status code is a server error (5xx),
+// or not set. If there's a `CustomError` in the chain, its attributes are
+// added to the event, see `Attributes`.
+func RecordErrorOnSpan(span trace.Span, err error) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+
+	var cE *customerror.CustomError
+	if !errors.As(err, &cE) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return
+	}
+
+	span.RecordError(err, trace.WithAttributes(Attributes(cE)...))
+
+	if isError(cE.StatusCode) {
+		span.SetStatus(codes.Error, cE.Message)
+	}
+}
+
+// WithTraceIDs sets the trace, and span IDs of the span of `ctx` as fields of
+// the error, correlating it to the trace.
+func WithTraceIDs(ctx context.Context) customerror.Option {
+	spanContext := trace.SpanContextFromContext(ctx)
+
+	return func(cE *customerror.CustomError) {
+		if !spanContext.IsValid() {
+			return
+		}
+
+		customerror.WithTypedField(FieldTraceID, spanContext.TraceID().String())(cE)
+		customerror.WithTypedField(FieldSpanID, spanContext.SpanID().String())(cE)
+	}
+}
+
+// RegisterContextExtractors registers context extractors for the trace, and
+// span IDs, so every error created with a context is correlated to the
+// trace.
+//
+// SEE `customerror.WithContext`.
+func RegisterContextExtractors() {
+	customerror.RegisterContextExtractor(FieldTraceID, func(ctx context.Context) (any, bool) {
+		spanContext := trace.SpanContextFromContext(ctx)
+
+		return spanContext.TraceID().String(), spanContext.HasTraceID()
+	})
+
+	customerror.RegisterContextExtractor(FieldSpanID, func(ctx context.Context) (any, bool) {
+		spanContext := trace.SpanContextFromContext(ctx)
+
+		return spanContext.SpanID().String(), spanContext.HasSpanID()
+	})
+}
@@ -0,0 +1,167 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalesfsp/customerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newTracer returns a tracer which exports to memory.
+func newTracer(t *testing.T) (*tracetest.InMemoryExporter, func(ctx context.Context, err error)) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	tracer := provider.Tracer("test")
+
+	return exporter, func(ctx context.Context, err error) {
+		ctx, span := tracer.Start(ctx, "operation")
+
+		RecordError(ctx, err)
+
+		span.End()
+	}
+}
+
+// attributesToMap converts attributes to a map, for easy comparison.
+func attributesToMap(attrs []attribute.KeyValue) map[string]any {
+	m := make(map[string]any, len(attrs))
+
+	for _, attr := range attrs {
+		m[string(attr.Key)] = attr.Value.AsInterface()
+	}
+
+	return m
+}
+
+func TestRecordError(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		wantStatus      codes.Code
+		wantAttributes  map[string]any
+		wantStacktrace  bool
+		wantDescription string
+	}{
+		{
+			name: "Should work - server error",
+			err: customerror.NewFailedToError(
+				"charge card",
+				customerror.WithErrorCode("E1010"),
+				customerror.WithTag("payment"),
+				customerror.WithField("amount", 42),
+				customerror.WithSensitiveField("card", "4111111111111111"),
+				customerror.WithStack(),
+			),
+			wantStatus:      codes.Error,
+			wantDescription: "failed to charge card",
+			wantStacktrace:  true,
+			wantAttributes: map[string]any{
+				"exception.type":           "*customerror.CustomError",
+				"exception.message":        "E1010: failed to charge card. Tags: payment. Fields: amount=42, card=[REDACTED]",
+				"customerror.code":         "E1010",
+				"customerror.status_code":  int64(http.StatusInternalServerError),
+				"customerror.severity":     "error",
+				"customerror.tags":         []string{"payment"},
+				"customerror.field.amount": int64(42),
+				"customerror.field.card":   "[REDACTED]",
+			},
+		},
+		{
+			name:       "Should work - client error doesn't set status",
+			err:        customerror.NewMissingError("id"),
+			wantStatus: codes.Unset,
+			wantAttributes: map[string]any{
+				"exception.type":          "*customerror.CustomError",
+				"exception.message":       "missing id",
+				"customerror.status_code": int64(http.StatusBadRequest),
+				"customerror.severity":    "warning",
+			},
+		},
+		{
+			name:            "Should work - not a custom error",
+			err:             errors.New("some error"),
+			wantStatus:      codes.Error,
+			wantDescription: "some error",
+			wantAttributes: map[string]any{
+				"exception.type":    "*errors.errorString",
+				"exception.message": "some error",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter, record := newTracer(t)
+
+			record(context.Background(), tt.err)
+
+			spans := exporter.GetSpans()
+			assert.Len(t, spans, 1)
+
+			span := spans[0]
+			assert.Equal(t, tt.wantStatus, span.Status.Code)
+			assert.Equal(t, tt.wantDescription, span.Status.Description)
+			assert.Len(t, span.Events, 1)
+			assert.Equal(t, "exception", span.Events[0].Name)
+
+			attrs := attributesToMap(span.Events[0].Attributes)
+
+			stacktrace, hasStacktrace := attrs["exception.stacktrace"]
+			assert.Equal(t, tt.wantStacktrace, hasStacktrace)
+
+			if hasStacktrace {
+				assert.True(t, strings.HasPrefix(stacktrace.(string), "github.com/thalesfsp/customerror/tracing.TestRecordError"))
+
+				delete(attrs, "exception.stacktrace")
+			}
+
+			assert.Equal(t, tt.wantAttributes, attrs)
+		})
+	}
+}
+
+func TestWithTraceIDs(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "operation")
+	defer span.End()
+
+	err := customerror.NewMissingError("id", WithTraceIDs(ctx))
+
+	traceID, ok := FieldTraceID.Get(err)
+	assert.True(t, ok)
+	assert.Equal(t, span.SpanContext().TraceID().String(), traceID)
+
+	spanID, ok := FieldSpanID.Get(err)
+	assert.True(t, ok)
+	assert.Equal(t, span.SpanContext().SpanID().String(), spanID)
+
+	// No span, no fields.
+	_, ok = FieldTraceID.Get(customerror.NewMissingError("id", WithTraceIDs(context.Background())))
+	assert.False(t, ok)
+
+	// Through context extractors.
+	RegisterContextExtractors()
+
+	traceID, ok = FieldTraceID.Get(customerror.NewCtx(ctx, "some error"))
+	assert.True(t, ok)
+	assert.Equal(t, span.SpanContext().TraceID().String(), traceID)
+
+	_, ok = FieldTraceID.Get(customerror.NewCtx(context.Background(), "some error"))
+	assert.False(t, ok)
+}